@@ -0,0 +1,104 @@
+// Command import loads recipes into the database from a JSON array, a CSV
+// file or schema.org JSON-LD, the same way POST /recipes/import does.
+//
+//	go run ./cmd/import -dry-run recipes.csv
+//
+// The database and cache are configured the same way as the API server,
+// through the config package.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/config"
+	"github.com/tolopsy/foodpro/api/importer"
+	"github.com/tolopsy/foodpro/api/logging"
+	"github.com/tolopsy/foodpro/api/provider"
+)
+
+func main() {
+	format := flag.String("format", "", "json, csv or jsonld; guessed from the file extension when omitted")
+	dryRun := flag.Bool("dry-run", false, "validate the records without storing them")
+	batchSize := flag.Int("batch-size", importer.DefaultBatchSize, "number of recipes inserted per batch")
+	flag.Usage = func() {
+		log.Println("usage: import [flags] [file]  (reads standard input without a file)")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var input io.Reader = os.Stdin
+	if path := flag.Arg(0); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatal("Error while opening import file -> " + err.Error())
+		}
+		defer file.Close()
+		input = file
+		if *format == "" {
+			*format = formatFromExtension(path)
+		}
+	}
+	if *format == "" {
+		log.Fatal("Import format must be given with -format when reading standard input")
+	}
+
+	records, err := importer.Parse(importer.Format(*format), input)
+	if err != nil {
+		log.Fatal("Error while parsing import -> " + err.Error())
+	}
+
+	settings, err := config.Load()
+	if err != nil {
+		log.Fatal("Error while loading configuration -> " + err.Error())
+	}
+	if err = settings.Database.Validate(); err != nil {
+		log.Fatal(err.Error())
+	}
+	logger := logging.New(settings.Logging)
+	db, err := provider.NewDBHandler(settings.Database.Type, settings.Database.URI, settings.Database.Name, logger)
+	if err != nil {
+		log.Fatal("Error while obtaining db handler -> " + err.Error())
+	}
+
+	report, err := importer.Import(db, records, *batchSize, *dryRun)
+	// closed explicitly, as the exits below skip deferred calls
+	db.Close()
+	if report.Imported > 0 {
+		// the API caches the full recipe list, which is now stale
+		cache, cacheErr := provider.NewCacheHandler(settings.Cache.Type, settings.Cache.Host, settings.Cache.Password, logger)
+		if cacheErr == nil {
+			cacheErr = cache.ClearRecipes()
+			cache.Close()
+		}
+		if cacheErr != nil {
+			log.Println("Error while clearing cached recipes -> " + cacheErr.Error())
+		}
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(report)
+	if err != nil {
+		log.Fatal("Error while storing recipes -> " + err.Error())
+	}
+	if len(report.Errors) > 0 {
+		os.Exit(2)
+	}
+}
+
+func formatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return string(importer.CSV)
+	case ".jsonld":
+		return string(importer.JSONLD)
+	case ".json":
+		return string(importer.JSON)
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+// Package config loads the API's settings. Values come from built in
+// defaults, then an optional YAML or TOML file, then a .env file, then the
+// process environment, each overriding the one before.
+package config
+
+import (
+	"time"
+)
+
+type Config struct {
+	Server   Server   `yaml:"server" toml:"server"`
+	Database Database `yaml:"database" toml:"database"`
+	Cache    Cache    `yaml:"cache" toml:"cache"`
+	Session  Session  `yaml:"session" toml:"session"`
+	Images   Images   `yaml:"images" toml:"images"`
+	CORS     CORS     `yaml:"cors" toml:"cors"`
+	Logging  Logging  `yaml:"logging" toml:"logging"`
+	Tracing  Tracing  `yaml:"tracing" toml:"tracing"`
+}
+
+type Server struct {
+	Address string `yaml:"address" toml:"address" env:"SERVER_ADDRESS"`
+	// where the gRPC RecipeService listens; it is off while this is empty,
+	// as it is by default
+	GRPCAddress string `yaml:"grpc_address" toml:"grpc_address" env:"SERVER_GRPC_ADDRESS"`
+	// how long in-flight requests get to finish, and connections to close,
+	// once a shutdown signal arrives
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+	// usernames allowed to read /status; nobody can when empty
+	Admins []string `yaml:"admins" toml:"admins" env:"SERVER_ADMINS"`
+}
+
+type Database struct {
+	Type string `yaml:"type" toml:"type" env:"DB_TYPE"`
+	// the URI may carry credentials, so its password is redacted
+	URI  string `yaml:"uri" toml:"uri" env:"DB_URI" secret:"url"`
+	Name string `yaml:"name" toml:"name" env:"DB_NAME"`
+}
+
+type Cache struct {
+	Type     string `yaml:"type" toml:"type" env:"CACHE_TYPE"`
+	Host     string `yaml:"host" toml:"host" env:"CACHE_HOST"`
+	Password string `yaml:"password" toml:"password" env:"CACHE_PASSWORD" secret:"true"`
+}
+
+type Session struct {
+	Address  string `yaml:"address" toml:"address" env:"SESS_STORE_ADDRESS"`
+	Password string `yaml:"password" toml:"password" env:"SESS_STORE_PASSWORD" secret:"true"`
+	Key      string `yaml:"key" toml:"key" env:"SESS_STORE_KEY" secret:"true"`
+}
+
+type Images struct {
+	BlobType     string `yaml:"blob_type" toml:"blob_type" env:"BLOB_TYPE"`
+	BlobLocation string `yaml:"blob_location" toml:"blob_location" env:"BLOB_LOCATION"`
+	BaseURL      string `yaml:"base_url" toml:"base_url" env:"IMAGE_BASE_URL"`
+	// in bytes
+	MaxSize int64 `yaml:"max_size" toml:"max_size" env:"IMAGE_MAX_SIZE"`
+}
+
+// Logging picks the level, one of debug, info, warn or error, and the
+// format, json or text, of the server's logs.
+type Logging struct {
+	Level  string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT"`
+}
+
+// Tracing chooses where spans are sent: nowhere ("none"), to an OpenTelemetry
+// collector over OTLP/gRPC ("otlp"), or to standard output ("stdout") for
+// local debugging.
+type Tracing struct {
+	Exporter    string `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER"`
+	ServiceName string `yaml:"service_name" toml:"service_name" env:"TRACING_SERVICE_NAME"`
+	// host:port of the collector's OTLP gRPC receiver
+	Endpoint string `yaml:"endpoint" toml:"endpoint" env:"TRACING_ENDPOINT"`
+	// send to the collector without TLS
+	Insecure bool `yaml:"insecure" toml:"insecure" env:"TRACING_INSECURE"`
+	// fraction of new traces to record, from 0 to 1; traces started by a
+	// caller follow the caller's decision
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// CORS holds separate policies for the public routes and for the routes
+// that require authentication.
+type CORS struct {
+	Public     CORSRule `yaml:"public" toml:"public" env:"CORS_PUBLIC_"`
+	Authorized CORSRule `yaml:"authorized" toml:"authorized" env:"CORS_AUTHORIZED_"`
+}
+
+// CORSRule is one cross-origin policy. Origins are exact, such as
+// https://app.example.com, patterns where * stands for one or more host
+// labels, such as https://*.example.com, or * alone for any origin.
+type CORSRule struct {
+	AllowOrigins     []string      `yaml:"allow_origins" toml:"allow_origins" env:"ALLOW_ORIGINS"`
+	AllowMethods     []string      `yaml:"allow_methods" toml:"allow_methods" env:"ALLOW_METHODS"`
+	AllowHeaders     []string      `yaml:"allow_headers" toml:"allow_headers" env:"ALLOW_HEADERS"`
+	ExposeHeaders    []string      `yaml:"expose_headers" toml:"expose_headers" env:"EXPOSE_HEADERS"`
+	AllowCredentials bool          `yaml:"allow_credentials" toml:"allow_credentials" env:"ALLOW_CREDENTIALS"`
+	MaxAge           time.Duration `yaml:"max_age" toml:"max_age" env:"MAX_AGE"`
+}
+
+// Default returns the settings used for anything not configured.
+func Default() Config {
+	return Config{
+		Server:   Server{Address: ":8080", ShutdownTimeout: 15 * time.Second},
+		Database: Database{Type: "mongodb"},
+		Cache:    Cache{Type: "redis", Host: "localhost:6379"},
+		Session:  Session{Address: "localhost:6379"},
+		Images: Images{
+			BlobType:     "local",
+			BlobLocation: "uploads",
+			BaseURL:      "/images",
+			MaxSize:      5 << 20,
+		},
+		CORS: CORS{
+			Public: CORSRule{
+				AllowOrigins:     []string{"http://localhost:3000"},
+				AllowMethods:     []string{"GET", "HEAD", "OPTIONS"},
+				AllowHeaders:     []string{"Origin", "Authorization", "X-API-KEY", "If-None-Match", "X-Request-ID", "traceparent", "tracestate"},
+				ExposeHeaders:    []string{"Content-Length", "Content-Disposition", "ETag", "X-Request-ID"},
+				AllowCredentials: true,
+				MaxAge:           12 * time.Hour,
+			},
+			Authorized: CORSRule{
+				AllowOrigins:     []string{"http://localhost:3000"},
+				AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+				AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-API-KEY", "If-Match", "If-None-Match", "X-Request-ID", "traceparent", "tracestate"},
+				ExposeHeaders:    []string{"Content-Length", "Content-Disposition", "ETag", "X-Request-ID"},
+				AllowCredentials: true,
+				MaxAge:           12 * time.Hour,
+			},
+		},
+		Logging: Logging{Level: "info", Format: "json"},
+		Tracing: Tracing{Exporter: "none", ServiceName: "foodpro-api", Endpoint: "localhost:4317", SampleRatio: 1},
+	}
+}
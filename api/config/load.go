@@ -0,0 +1,158 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v2"
+)
+
+// FileVariable names the environment variable that points at a config
+// file. Without it the first of DefaultFiles found in the working directory
+// is used, if any.
+const FileVariable = "CONFIG_FILE"
+
+var DefaultFiles = []string{"config.yaml", "config.yml", "config.toml"}
+
+var ErrorUnknownFileType = errors.New("config file must end in .yaml, .yml or .toml")
+
+// Load builds the configuration from every source. A missing .env file or
+// default config file is not an error, but a CONFIG_FILE that cannot be
+// read is. The result is not validated; call Validate for that.
+func Load() (Config, error) {
+	config := Default()
+
+	path, explicit := os.LookupEnv(FileVariable)
+	if !explicit {
+		for _, candidate := range DefaultFiles {
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+	}
+	if path != "" {
+		if err := loadFile(path, &config); err != nil {
+			return config, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	dotenv, err := godotenv.Read()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return config, fmt.Errorf(".env: %w", err)
+	}
+	lookup := func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := dotenv[name]
+		return value, ok
+	}
+	if err := applyEnvironment(reflect.ValueOf(&config).Elem(), "", lookup); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+func loadFile(path string, config *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return yaml.UnmarshalStrict(data, config)
+	case ".toml":
+		metadata, err := toml.Decode(string(data), config)
+		if err != nil {
+			return err
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("unknown setting %s", undecoded[0])
+		}
+		return nil
+	default:
+		return ErrorUnknownFileType
+	}
+}
+
+// applyEnvironment sets every field tagged with env whose variable is set.
+// On a nested struct the env tag is a prefix for the names of its fields.
+// Lists are read comma separated and durations as in "12h".
+func applyEnvironment(section reflect.Value, prefix string, lookup func(string) (string, bool)) error {
+	for i := 0; i < section.NumField(); i++ {
+		field, value := section.Type().Field(i), section.Field(i)
+		name := field.Tag.Get("env")
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyEnvironment(value, prefix+name, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		raw, ok := lookup(prefix + name)
+		if !ok {
+			continue
+		}
+		if err := setField(value, strings.TrimSpace(raw)); err != nil {
+			return fmt.Errorf("%s: %w", prefix+name, err)
+		}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setField(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		number, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		value.SetInt(number)
+	case reflect.Float64:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		value.SetFloat(number)
+	case reflect.Bool:
+		flag, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.New("must be true or false")
+		}
+		value.SetBool(flag)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return errors.New("unsupported setting type " + value.Type().String())
+	}
+	return nil
+}
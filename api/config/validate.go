@@ -0,0 +1,215 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/provider"
+)
+
+// ValidationError lists every problem found in a configuration at once, so
+// they can all be fixed in one go.
+type ValidationError []string
+
+func (err ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(err, "; ")
+}
+
+// Validate checks every section and returns a ValidationError if any is
+// invalid.
+func (config Config) Validate() error {
+	var problems ValidationError
+	for _, section := range []interface{ problems() []string }{
+		config.Server, config.Database, config.Cache, config.Session, config.Images, config.CORS, config.Logging, config.Tracing,
+	} {
+		problems = append(problems, section.problems()...)
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+	return nil
+}
+
+// Validate checks the database settings alone, for tools that need
+// nothing else.
+func (database Database) Validate() error {
+	if problems := database.problems(); len(problems) > 0 {
+		return ValidationError(problems)
+	}
+	return nil
+}
+
+func (server Server) problems() []string {
+	var problems []string
+	if !strings.Contains(server.Address, ":") {
+		problems = append(problems, "server.address must be a host:port address such as :8080")
+	}
+	if server.GRPCAddress != "" && !strings.Contains(server.GRPCAddress, ":") {
+		problems = append(problems, "server.grpc_address must be a host:port address such as :9090, or empty")
+	}
+	if server.GRPCAddress != "" && server.GRPCAddress == server.Address {
+		problems = append(problems, "server.grpc_address must differ from server.address")
+	}
+	if server.ShutdownTimeout <= 0 {
+		problems = append(problems, "server.shutdown_timeout must be positive")
+	}
+	return problems
+}
+
+func (database Database) problems() []string {
+	var problems []string
+	if provider.DBTYPE(database.Type) != provider.MONGO_DB {
+		problems = append(problems, fmt.Sprintf("database.type %q is not supported", database.Type))
+	}
+	if database.URI == "" {
+		problems = append(problems, "database.uri is required")
+	}
+	if database.Name == "" {
+		problems = append(problems, "database.name is required")
+	}
+	return problems
+}
+
+func (cache Cache) problems() []string {
+	var problems []string
+	if provider.CACHE_SERVER(cache.Type) != provider.REDIS {
+		problems = append(problems, fmt.Sprintf("cache.type %q is not supported", cache.Type))
+	}
+	if cache.Host == "" {
+		problems = append(problems, "cache.host is required")
+	}
+	return problems
+}
+
+func (session Session) problems() []string {
+	var problems []string
+	if session.Address == "" {
+		problems = append(problems, "session.address is required")
+	}
+	if session.Key == "" {
+		problems = append(problems, "session.key is required to sign session cookies")
+	}
+	return problems
+}
+
+func (images Images) problems() []string {
+	var problems []string
+	if provider.BLOB_STORE(images.BlobType) != provider.LOCAL_FS {
+		problems = append(problems, fmt.Sprintf("images.blob_type %q is not supported", images.BlobType))
+	}
+	if images.BlobLocation == "" {
+		problems = append(problems, "images.blob_location is required")
+	}
+	if images.MaxSize <= 0 {
+		problems = append(problems, "images.max_size must be a positive number of bytes")
+	}
+	return problems
+}
+
+func (logging Logging) problems() []string {
+	var problems []string
+	switch logging.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("logging.level %q must be debug, info, warn or error", logging.Level))
+	}
+	if logging.Format != "json" && logging.Format != "text" {
+		problems = append(problems, fmt.Sprintf("logging.format %q must be json or text", logging.Format))
+	}
+	return problems
+}
+
+func (tracing Tracing) problems() []string {
+	var problems []string
+	switch tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if tracing.Endpoint == "" {
+			problems = append(problems, "tracing.endpoint is required to export over OTLP")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("tracing.exporter %q must be none, otlp or stdout", tracing.Exporter))
+	}
+	if tracing.ServiceName == "" {
+		problems = append(problems, "tracing.service_name is required")
+	}
+	if tracing.SampleRatio < 0 || tracing.SampleRatio > 1 {
+		problems = append(problems, "tracing.sample_ratio must be between 0 and 1")
+	}
+	return problems
+}
+
+func (cors CORS) problems() []string {
+	return append(cors.Public.problems("cors.public"), cors.Authorized.problems("cors.authorized")...)
+}
+
+var (
+	originPattern = regexp.MustCompile(`^https?://[a-zA-Z0-9*.-]+(:\d+)?$`)
+	httpMethods   = map[string]bool{
+		"GET": true, "HEAD": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "OPTIONS": true,
+	}
+)
+
+func (rule CORSRule) problems(name string) []string {
+	var problems []string
+	if len(rule.AllowOrigins) == 0 {
+		problems = append(problems, name+".allow_origins needs at least one origin")
+	}
+	for _, origin := range rule.AllowOrigins {
+		if origin == "*" {
+			if rule.AllowCredentials {
+				problems = append(problems, name+".allow_origins cannot be * while allow_credentials is set")
+			}
+			continue
+		}
+		if !originPattern.MatchString(origin) {
+			problems = append(problems, fmt.Sprintf("%s.allow_origins entry %q must be a scheme and host such as https://*.example.com", name, origin))
+		}
+	}
+	for _, method := range rule.AllowMethods {
+		if !httpMethods[strings.ToUpper(method)] {
+			problems = append(problems, fmt.Sprintf("%s.allow_methods entry %q is not an HTTP method", name, method))
+		}
+	}
+	if rule.MaxAge < 0 {
+		problems = append(problems, name+".max_age cannot be negative")
+	}
+	return problems
+}
+
+// Redacted renders the effective configuration one setting per line, as
+// section.setting = value, with secrets masked.
+func (config Config) Redacted() string {
+	return strings.Join(redact(reflect.ValueOf(config), ""), "\n")
+}
+
+func redact(section reflect.Value, prefix string) []string {
+	var lines []string
+	for i := 0; i < section.NumField(); i++ {
+		field := section.Type().Field(i)
+		name := prefix + field.Tag.Get("yaml")
+		if field.Type.Kind() == reflect.Struct {
+			lines = append(lines, redact(section.Field(i), name+".")...)
+			continue
+		}
+
+		value := fmt.Sprint(section.Field(i).Interface())
+		switch field.Tag.Get("secret") {
+		case "true":
+			if value != "" {
+				value = "********"
+			}
+		case "url":
+			if parsed, err := url.Parse(value); err == nil {
+				value = parsed.Redacted()
+			} else {
+				value = "********"
+			}
+		}
+		lines = append(lines, name+" = "+value)
+	}
+	return lines
+}
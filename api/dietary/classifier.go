@@ -0,0 +1,73 @@
+// Package dietary derives allergens and diet labels from the ingredients of
+// a recipe with a set of keyword rules.
+package dietary
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+const (
+	Vegan      = "vegan"
+	Vegetarian = "vegetarian"
+	Keto       = "keto"
+)
+
+// Classify returns the allergens the ingredients contain and the diets they
+// are suitable for, both sorted. Optional ingredients count, since a cook
+// may well add them.
+func Classify(ingredients []persistence.Ingredient) (allergens []string, diets []string) {
+	if len(ingredients) == 0 {
+		return nil, nil
+	}
+
+	found := make(map[string]bool)
+	hasMeat, hasAnimalProducts, hasCarbs := false, false, false
+	for _, ingredient := range ingredients {
+		item := " " + strings.ToLower(ingredient.Item) + " "
+		for allergen, rule := range allergenRules {
+			if rule.matches(item) {
+				found[allergen] = true
+			}
+		}
+		hasMeat = hasMeat || meat.matches(item) || seafood.matches(item)
+		hasAnimalProducts = hasAnimalProducts || animalProducts.matches(item)
+		hasCarbs = hasCarbs || highCarb.matches(item)
+	}
+
+	allergens = make([]string, 0, len(found))
+	for allergen := range found {
+		allergens = append(allergens, allergen)
+	}
+	sort.Strings(allergens)
+
+	diets = make([]string, 0, 3)
+	if !hasCarbs {
+		diets = append(diets, Keto)
+	}
+	if !hasMeat && !hasAnimalProducts {
+		diets = append(diets, Vegan)
+	}
+	if !hasMeat {
+		diets = append(diets, Vegetarian)
+	}
+	return allergens, diets
+}
+
+// matches expects item to be lowercased and padded with spaces so keywords
+// only match whole words.
+func (r rule) matches(item string) bool {
+	for _, exception := range r.exceptions {
+		if strings.Contains(item, exception) {
+			return false
+		}
+	}
+	for _, keyword := range r.keywords {
+		if strings.Contains(item, " "+keyword+" ") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package dietary
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+func ingredients(items ...string) []persistence.Ingredient {
+	result := make([]persistence.Ingredient, len(items))
+	for i, item := range items {
+		result[i] = persistence.Ingredient{Item: item}
+	}
+	return result
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name          string
+		ingredients   []persistence.Ingredient
+		wantAllergens []string
+		wantDiets     []string
+	}{
+		{"pancakes", ingredients("all-purpose flour", "Milk", "eggs", "sugar"), []string{"dairy", "eggs", "gluten"}, []string{Vegetarian}},
+		{"tofu stir fry", ingredients("firm tofu", "broccoli", "soy sauce", "vegetable stock"), []string{"gluten", "soy"}, []string{Keto, Vegan, Vegetarian}},
+		{"satay chicken", ingredients("chicken thighs", "peanut butter"), []string{"peanuts"}, []string{Keto}},
+		{"smoothie", ingredients("almond milk", "honey"), []string{"nuts"}, []string{Vegetarian}},
+		{"baba ganoush", ingredients("eggplant", "tahini", "lemon"), []string{"sesame"}, []string{Keto, Vegan, Vegetarian}},
+		{"rice flour crepes", ingredients("rice flour", "coconut milk"), []string{}, []string{Vegan, Vegetarian}},
+	}
+	for _, test := range tests {
+		allergens, diets := Classify(test.ingredients)
+		if !reflect.DeepEqual(allergens, test.wantAllergens) || !reflect.DeepEqual(diets, test.wantDiets) {
+			t.Errorf("%s: Classify = %q, %q, want %q, %q", test.name, allergens, diets, test.wantAllergens, test.wantDiets)
+		}
+	}
+}
+
+// Optional ingredients count, since a cook may well add them.
+func TestClassifyCountsOptionalIngredients(t *testing.T) {
+	recipe := []persistence.Ingredient{{Item: "lentils"}, {Item: "feta", Optional: true}}
+	if allergens, diets := Classify(recipe); !reflect.DeepEqual(allergens, []string{"dairy"}) || !reflect.DeepEqual(diets, []string{Vegetarian}) {
+		t.Errorf("Classify = %q, %q, want dairy and vegetarian", allergens, diets)
+	}
+}
+
+func TestClassifyWithoutIngredients(t *testing.T) {
+	if allergens, diets := Classify(nil); allergens != nil || diets != nil {
+		t.Errorf("Classify(nil) = %q, %q, want nil, nil", allergens, diets)
+	}
+}
@@ -0,0 +1,94 @@
+package dietary
+
+// rule flags an ingredient when its item contains any of keywords as whole
+// words, unless it also contains one of the exceptions.
+type rule struct {
+	keywords   []string
+	exceptions []string
+}
+
+var meat = rule{
+	keywords: []string{
+		"beef", "pork", "bacon", "ham", "chicken", "turkey", "duck", "lamb", "veal",
+		"sausage", "prosciutto", "salami", "pepperoni", "chorizo", "mince", "steak",
+		"gelatin", "gelatine", "lard", "stock", "broth",
+	},
+	exceptions: []string{"vegetable stock", "vegetable broth", "veggie stock", "mushroom broth", "plant-based"},
+}
+
+var seafood = rule{
+	keywords: []string{
+		"fish", "salmon", "tuna", "cod", "anchovy", "anchovies", "sardine", "trout",
+		"halibut", "tilapia", "mackerel", "haddock", "shrimp", "prawn", "crab",
+		"lobster", "scallop", "mussel", "clam", "oyster", "squid", "calamari", "fish sauce",
+	},
+}
+
+var allergenRules = map[string]rule{
+	"gluten": {
+		keywords: []string{
+			"flour", "wheat", "bread", "breadcrumbs", "pasta", "spaghetti", "penne",
+			"macaroni", "noodles", "couscous", "barley", "rye", "semolina", "bulgur",
+			"seitan", "soy sauce", "beer", "tortilla", "crackers",
+		},
+		exceptions: []string{
+			"gluten-free", "gluten free", "rice flour", "almond flour", "coconut flour",
+			"corn tortilla", "rice noodles", "tamari", "buckwheat",
+		},
+	},
+	"dairy": {
+		keywords: []string{
+			"milk", "butter", "cream", "cheese", "cheddar", "parmesan", "mozzarella",
+			"ricotta", "yogurt", "yoghurt", "ghee", "buttermilk", "whey", "feta", "custard",
+		},
+		exceptions: []string{
+			"coconut milk", "almond milk", "soy milk", "oat milk", "rice milk", "coconut cream",
+			"peanut butter", "almond butter", "cashew butter", "cocoa butter", "cream of tartar",
+			"butternut", "dairy-free", "vegan",
+		},
+	},
+	"eggs": {
+		keywords:   []string{"egg", "eggs", "mayonnaise", "meringue", "aioli"},
+		exceptions: []string{"eggplant", "egg-free", "vegan mayonnaise"},
+	},
+	"nuts": {
+		keywords: []string{
+			"almond", "almonds", "walnut", "walnuts", "pecan", "pecans", "cashew", "cashews",
+			"hazelnut", "hazelnuts", "pistachio", "pistachios", "macadamia", "pine nuts",
+			"brazil nut", "nuts", "praline", "marzipan", "nutella",
+		},
+		exceptions: []string{"nutmeg", "coconut", "butternut", "water chestnut"},
+	},
+	"peanuts": {
+		keywords: []string{"peanut", "peanuts", "peanut butter", "groundnut"},
+	},
+	"shellfish": {
+		keywords: []string{
+			"shrimp", "prawn", "prawns", "crab", "lobster", "scallop", "scallops",
+			"mussel", "mussels", "clam", "clams", "oyster", "oysters", "crayfish",
+		},
+	},
+	"fish":   seafood,
+	"soy":    {keywords: []string{"soy", "soya", "tofu", "tempeh", "edamame", "miso", "tamari"}},
+	"sesame": {keywords: []string{"sesame", "tahini"}},
+}
+
+// animalProducts are not vegan even though they are vegetarian.
+var animalProducts = rule{
+	keywords: []string{
+		"milk", "butter", "cream", "cheese", "cheddar", "parmesan", "mozzarella",
+		"ricotta", "yogurt", "yoghurt", "ghee", "buttermilk", "whey", "feta",
+		"egg", "eggs", "mayonnaise", "honey",
+	},
+	exceptions: allergenRules["dairy"].exceptions,
+}
+
+// highCarb ingredients rule out a keto label.
+var highCarb = rule{
+	keywords: []string{
+		"sugar", "honey", "syrup", "flour", "bread", "pasta", "spaghetti", "rice",
+		"potato", "potatoes", "noodles", "oats", "corn", "beans", "lentils",
+		"chickpeas", "banana", "bananas", "couscous", "quinoa", "tortilla",
+	},
+	exceptions: []string{"almond flour", "coconut flour", "cauliflower rice", "sugar-free", "green beans"},
+}
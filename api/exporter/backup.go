@@ -0,0 +1,72 @@
+package exporter
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+type BackupFormat string
+
+const (
+	NDJSON BackupFormat = "ndjson"
+	Zip    BackupFormat = "zip"
+)
+
+var ErrorUnknownBackupFormat = errors.New("backup format must be either ndjson or zip")
+
+// Source feeds recipes one at a time, as persistence.DatabaseHandler's
+// EachRecipe does, so backups never hold the whole collection in memory.
+type Source func(func(persistence.Recipe) error) error
+
+// WriteBackup streams every recipe from source. NDJSON writes one API JSON
+// document per line. Zip writes each recipe as JSON, which the importer
+// reads back, alongside a Markdown copy for people.
+func WriteBackup(writer io.Writer, format BackupFormat, source Source) error {
+	switch format {
+	case NDJSON:
+		encoder := json.NewEncoder(writer)
+		return source(func(recipe persistence.Recipe) error {
+			return encoder.Encode(recipe)
+		})
+	case Zip:
+		return writeZip(writer, source)
+	default:
+		return ErrorUnknownBackupFormat
+	}
+}
+
+func writeZip(writer io.Writer, source Source) error {
+	archive := zip.NewWriter(writer)
+	now := time.Now()
+	create := func(name string) (io.Writer, error) {
+		return archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now})
+	}
+
+	err := source(func(recipe persistence.Recipe) error {
+		id := recipe.IDString()
+		file, err := create("recipes/" + id + ".json")
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(recipe); err != nil {
+			return err
+		}
+
+		file, err = create("markdown/" + id + "-" + Filename(recipe, Markdown))
+		if err != nil {
+			return err
+		}
+		return writeMarkdown(file, recipe, "")
+	})
+	if err != nil {
+		return err
+	}
+	return archive.Close()
+}
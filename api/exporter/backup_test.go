@@ -0,0 +1,69 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+func source(recipes ...persistence.Recipe) Source {
+	return func(fn func(persistence.Recipe) error) error {
+		for _, recipe := range recipes {
+			if err := fn(recipe); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestWriteBackupNDJSON(t *testing.T) {
+	var buffer bytes.Buffer
+	err := WriteBackup(&buffer, NDJSON, source(persistence.Recipe{ID: "a", Name: "One"}, persistence.Recipe{ID: "b", Name: "Two"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("backup has %d lines, want 2", len(lines))
+	}
+	var recipe persistence.Recipe
+	if err := json.Unmarshal([]byte(lines[1]), &recipe); err != nil || recipe.Name != "Two" {
+		t.Errorf("second line decodes to %+v, %v", recipe, err)
+	}
+}
+
+func TestWriteBackupZip(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := WriteBackup(&buffer, Zip, source(testRecipe())); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, file := range archive.File {
+		names = append(names, file.Name)
+	}
+	want := []string{"recipes/abc123.json", "markdown/abc123-grandmas-apple-pie.md"}
+	if strings.Join(names, " ") != strings.Join(want, " ") {
+		t.Errorf("archive holds %q, want %q", names, want)
+	}
+}
+
+func TestWriteBackupStopsOnSourceError(t *testing.T) {
+	failure := errors.New("cursor failed")
+	failing := func(func(persistence.Recipe) error) error { return failure }
+	if err := WriteBackup(&bytes.Buffer{}, Zip, failing); err != failure {
+		t.Errorf("error = %v, want %v", err, failure)
+	}
+	if err := WriteBackup(&bytes.Buffer{}, "tar", source()); err != ErrorUnknownBackupFormat {
+		t.Errorf("error = %v, want %v", err, ErrorUnknownBackupFormat)
+	}
+}
@@ -0,0 +1,108 @@
+// Package exporter renders recipes in formats meant for people and other
+// recipe managers, and writes whole-collection backups.
+package exporter
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/schemaorg"
+)
+
+type Format string
+
+const (
+	JSONLD     Format = "jsonld"
+	Markdown   Format = "markdown"
+	Text       Format = "text"
+	Paprika    Format = "paprika"
+	MealMaster Format = "mealmaster"
+)
+
+var ErrorUnknownFormat = errors.New("export format must be one of jsonld, markdown, text, paprika or mealmaster")
+
+// ContentType returns the MIME type a format is served with.
+func (format Format) ContentType() string {
+	switch format {
+	case JSONLD:
+		return "application/ld+json"
+	case Markdown:
+		return "text/markdown; charset=utf-8"
+	case Paprika:
+		return "application/octet-stream"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Extension returns the file extension, including the dot, used when a
+// format is downloaded as a file.
+func (format Format) Extension() string {
+	switch format {
+	case JSONLD:
+		return ".jsonld"
+	case Markdown:
+		return ".md"
+	case Paprika:
+		return ".paprikarecipe"
+	case MealMaster:
+		return ".mmf"
+	default:
+		return ".txt"
+	}
+}
+
+// Write renders a single recipe in the given format. url is the recipe's
+// canonical address, used where a format can link back to the source.
+func Write(writer io.Writer, format Format, recipe persistence.Recipe, url string) error {
+	switch format {
+	case JSONLD:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(schemaorg.FromRecipe(recipe, url))
+	case Markdown:
+		return writeMarkdown(writer, recipe, url)
+	case Text:
+		return writeText(writer, recipe)
+	case Paprika:
+		return writePaprika(writer, recipe, url)
+	case MealMaster:
+		return writeMealMaster(writer, recipe)
+	default:
+		return ErrorUnknownFormat
+	}
+}
+
+// Filename names the download of a recipe in the given format.
+func Filename(recipe persistence.Recipe, format Format) string {
+	return Slug(recipe) + format.Extension()
+}
+
+// Slug builds a file name from the recipe name, falling back to its id
+// when the name has nothing usable in it.
+func Slug(recipe persistence.Recipe) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(recipe.Name) {
+		if r == '\'' || r == '’' {
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && builder.Len() > 0 {
+				builder.WriteRune('-')
+			}
+			builder.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if builder.Len() == 0 {
+		return recipe.IDString()
+	}
+	return builder.String()
+}
@@ -0,0 +1,120 @@
+package exporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/schemaorg"
+)
+
+func testRecipe() persistence.Recipe {
+	return persistence.Recipe{
+		ID:       "abc123",
+		Name:     "Grandma's Apple Pie",
+		Tags:     []string{"dessert", "baking"},
+		Servings: 8,
+		Ingredients: []persistence.Ingredient{
+			{Quantity: 2.5, Unit: "cup", Item: "flour"},
+			{Quantity: 6, Item: "apples", Note: "peeled and sliced into thin half moons for the filling"},
+			{Item: "cinnamon", Optional: true},
+		},
+		Instructions: []string{"Make the pastry.", "Fill and bake."},
+	}
+}
+
+func export(t *testing.T, format Format) string {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := Write(&buffer, format, testRecipe(), "https://example.com/recipes/abc123"); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.String()
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	got := export(t, Markdown)
+	for _, want := range []string{
+		"# Grandma's Apple Pie\n\nServes 8\n\n*dessert, baking*\n\n",
+		"- 2 1/2 cup flour\n",
+		"- cinnamon (optional)\n",
+		"1. Make the pastry.\n2. Fill and bake.\n",
+		"[Source](https://example.com/recipes/abc123)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Markdown export is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	got := export(t, Text)
+	if !strings.HasPrefix(got, "GRANDMA'S APPLE PIE\nServes 8\n") || !strings.Contains(got, "  2. Fill and bake.\n") {
+		t.Errorf("unexpected text export:\n%s", got)
+	}
+}
+
+func TestWriteMealMaster(t *testing.T) {
+	got := export(t, MealMaster)
+	for _, want := range []string{
+		"      Title: Grandma's Apple Pie\n",
+		" Categories: dessert, baking\n",
+		"      Yield: 8 servings\n",
+		"  2 1/2 c  flour\n",
+		"      6    apples; peeled and sliced\n           -into thin half moons for the\n           -filling\n",
+		"MMMMM\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Meal-Master export is missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestWritePaprika(t *testing.T) {
+	reader, err := gzip.NewReader(strings.NewReader(export(t, Paprika)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document paprikaRecipe
+	if err := json.NewDecoder(reader).Decode(&document); err != nil {
+		t.Fatal(err)
+	}
+	if document.UID != "ABC123" || document.Servings != "8" || document.SourceURL != "https://example.com/recipes/abc123" ||
+		document.Directions != "Make the pastry.\n\nFill and bake." || len(document.Hash) != 64 {
+		t.Errorf("unexpected Paprika document: %+v", document)
+	}
+}
+
+// A JSON-LD export must read back as the same recipe.
+func TestWriteJSONLDRoundTrip(t *testing.T) {
+	nodes, err := schemaorg.FindRecipes([]byte(export(t, JSONLD)))
+	if err != nil || len(nodes) != 1 {
+		t.Fatalf("FindRecipes = %d nodes, %v", len(nodes), err)
+	}
+	recipe, problems := schemaorg.ToRecipe(nodes[0])
+	original := testRecipe()
+	if len(problems) != 0 || recipe.Name != original.Name || recipe.Servings != original.Servings ||
+		!reflect.DeepEqual(recipe.Instructions, original.Instructions) ||
+		!reflect.DeepEqual(recipe.Ingredients, original.Ingredients) {
+		t.Errorf("round trip gave %+v, %q", recipe, problems)
+	}
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, "pdf", testRecipe(), ""); err != ErrorUnknownFormat {
+		t.Errorf("error = %v, want %v", err, ErrorUnknownFormat)
+	}
+}
+
+func TestFilename(t *testing.T) {
+	if got := Filename(testRecipe(), Markdown); got != "grandmas-apple-pie.md" {
+		t.Errorf("Filename = %q, want grandmas-apple-pie.md", got)
+	}
+	if got := Filename(persistence.Recipe{ID: "abc123", Name: "!!!"}, Paprika); got != "abc123.paprikarecipe" {
+		t.Errorf("Filename of a recipe without a usable name = %q, want abc123.paprikarecipe", got)
+	}
+}
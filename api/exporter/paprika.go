@@ -0,0 +1,68 @@
+package exporter
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// paprikaRecipe mirrors the JSON document Paprika stores, gzipped, in a
+// .paprikarecipe file.
+type paprikaRecipe struct {
+	UID         string   `json:"uid"`
+	Name        string   `json:"name"`
+	Ingredients string   `json:"ingredients"`
+	Directions  string   `json:"directions"`
+	Servings    string   `json:"servings"`
+	Categories  []string `json:"categories"`
+	Source      string   `json:"source"`
+	SourceURL   string   `json:"source_url"`
+	ImageURL    string   `json:"image_url"`
+	Rating      int      `json:"rating"`
+	Created     string   `json:"created"`
+	Hash        string   `json:"hash"`
+}
+
+func writePaprika(writer io.Writer, recipe persistence.Recipe, url string) error {
+	ingredients := make([]string, len(recipe.Ingredients))
+	for i, ingredient := range recipe.Ingredients {
+		ingredients[i] = ingredient.String()
+	}
+	document := paprikaRecipe{
+		UID:         strings.ToUpper(recipe.IDString()),
+		Name:        recipe.Name,
+		Ingredients: strings.Join(ingredients, "\n"),
+		Directions:  strings.Join(recipe.Instructions, "\n\n"),
+		Categories:  recipe.Tags,
+		Source:      "foodpro",
+		SourceURL:   url,
+		Rating:      int(recipe.RatingAverage + 0.5),
+	}
+	if document.Categories == nil {
+		document.Categories = []string{}
+	}
+	if recipe.Servings > 0 {
+		document.Servings = strconv.Itoa(recipe.Servings)
+	}
+	if len(recipe.Images) > 0 {
+		document.ImageURL = recipe.Images[0].URL
+	}
+	if !recipe.PublishedAt.IsZero() {
+		document.Created = recipe.PublishedAt.UTC().Format("2006-01-02 15:04:05")
+	}
+	// Paprika uses the hash to notice changed recipes when re-importing.
+	sum := sha256.Sum256([]byte(document.Name + document.Ingredients + document.Directions))
+	document.Hash = strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	compressed := gzip.NewWriter(writer)
+	if err := json.NewEncoder(compressed).Encode(document); err != nil {
+		return err
+	}
+	return compressed.Close()
+}
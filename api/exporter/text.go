@@ -0,0 +1,145 @@
+package exporter
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/units"
+)
+
+func writeMarkdown(writer io.Writer, recipe persistence.Recipe, url string) error {
+	out := bufio.NewWriter(writer)
+	out.WriteString("# " + recipe.Name + "\n\n")
+	if recipe.Servings > 0 {
+		out.WriteString("Serves " + strconv.Itoa(recipe.Servings) + "\n\n")
+	}
+	if len(recipe.Tags) > 0 {
+		out.WriteString("*" + strings.Join(recipe.Tags, ", ") + "*\n\n")
+	}
+	if len(recipe.Images) > 0 {
+		out.WriteString("![" + recipe.Name + "](" + recipe.Images[0].URL + ")\n\n")
+	}
+
+	out.WriteString("## Ingredients\n\n")
+	for _, ingredient := range recipe.Ingredients {
+		out.WriteString("- " + ingredient.String() + "\n")
+	}
+	out.WriteString("\n## Instructions\n\n")
+	for i, instruction := range recipe.Instructions {
+		out.WriteString(strconv.Itoa(i+1) + ". " + instruction + "\n")
+	}
+	if url != "" {
+		out.WriteString("\n[Source](" + url + ")\n")
+	}
+	return out.Flush()
+}
+
+func writeText(writer io.Writer, recipe persistence.Recipe) error {
+	out := bufio.NewWriter(writer)
+	out.WriteString(strings.ToUpper(recipe.Name) + "\n")
+	if recipe.Servings > 0 {
+		out.WriteString("Serves " + strconv.Itoa(recipe.Servings) + "\n")
+	}
+
+	out.WriteString("\nIngredients\n")
+	for _, ingredient := range recipe.Ingredients {
+		out.WriteString("  * " + ingredient.String() + "\n")
+	}
+	out.WriteString("\nInstructions\n")
+	for i, instruction := range recipe.Instructions {
+		out.WriteString("  " + strconv.Itoa(i+1) + ". " + instruction + "\n")
+	}
+	return out.Flush()
+}
+
+// mealMasterUnits maps canonical units onto Meal-Master's two letter codes.
+// Units without a code are written in front of the item instead.
+var mealMasterUnits = map[string]string{
+	"tsp": "ts", "tbsp": "tb", "cup": "c", "fl oz": "fl", "pint": "pt",
+	"quart": "qt", "gallon": "ga", "ml": "ml", "l": "l", "g": "g", "kg": "kg",
+	"oz": "oz", "lb": "lb", "pinch": "pn", "dash": "ds", "can": "cn",
+	"slice": "sl", "bunch": "bn", "package": "pk",
+}
+
+const mealMasterItemWidth = 28
+
+// writeMealMaster writes the fixed column Meal-Master format understood by
+// most older recipe managers.
+func writeMealMaster(writer io.Writer, recipe persistence.Recipe) error {
+	out := bufio.NewWriter(writer)
+	out.WriteString("MMMMM----- Recipe via Meal-Master (tm) v8.05\n\n")
+	out.WriteString("      Title: " + recipe.Name + "\n")
+	out.WriteString(" Categories: " + strings.Join(recipe.Tags, ", ") + "\n")
+	servings := recipe.Servings
+	if servings <= 0 {
+		servings = 1
+	}
+	out.WriteString("      Yield: " + strconv.Itoa(servings) + " servings\n\n")
+
+	for _, ingredient := range recipe.Ingredients {
+		amount := ""
+		if ingredient.Quantity > 0 {
+			amount = units.Format(ingredient.Quantity, ingredient.Unit)
+		}
+		code, ok := mealMasterUnits[ingredient.Unit]
+		item := ingredient.Item
+		if !ok && ingredient.Unit != "" {
+			item = ingredient.Unit + " " + item
+		}
+		if ingredient.Note != "" {
+			item += "; " + ingredient.Note
+		}
+		if ingredient.Optional {
+			item += " (optional)"
+		}
+
+		// Long items continue on following lines with a leading dash, as
+		// the format only has room for a short column.
+		lines := wrap(item, mealMasterItemWidth)
+		out.WriteString(pad(amount, 7, true) + " " + pad(code, 2, false) + " " + lines[0] + "\n")
+		for _, line := range lines[1:] {
+			out.WriteString(strings.Repeat(" ", 11) + "-" + line + "\n")
+		}
+	}
+
+	out.WriteString("\n")
+	for _, instruction := range recipe.Instructions {
+		for _, line := range wrap(instruction, 72) {
+			out.WriteString("  " + line + "\n")
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString("MMMMM\n")
+	return out.Flush()
+}
+
+func pad(value string, width int, right bool) string {
+	if len(value) >= width {
+		return value
+	}
+	if right {
+		return strings.Repeat(" ", width-len(value)) + value
+	}
+	return value + strings.Repeat(" ", width-len(value))
+}
+
+// wrap breaks text into lines of at most width characters on word
+// boundaries. Words longer than a line are left whole.
+func wrap(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	return append(lines, line)
+}
@@ -0,0 +1,107 @@
+package graphql_api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	auth "github.com/tolopsy/foodpro/api/server/middleware/authentication"
+)
+
+const paramsKey = "graphqlParams"
+
+// Handler serves the GraphQL endpoint.
+type Handler struct {
+	db     persistence.DatabaseHandler
+	schema gql.Schema
+}
+
+func NewHandler(db persistence.DatabaseHandler, cache persistence.CacheHandler, blobs persistence.BlobStore, logger *slog.Logger) (*Handler, error) {
+	schema, err := newSchema(&resolver{db: db, cache: cache, blobs: blobs, logger: logger})
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{db: db, schema: schema}, nil
+}
+
+type params struct {
+	Query         string                 `json:"query" form:"query" binding:"required"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables" form:"-"`
+}
+
+// Authenticate reads the GraphQL request and runs auth's authentication
+// when it asks for a mutation, so queries stay public while writes need the
+// same credentials as the REST endpoints. Mutations sent with GET are
+// refused, as GET requests must be safe to repeat.
+func (handler *Handler) Authenticate(auth auth.AuthMiddleware) gin.HandlerFunc {
+	authenticate := auth.Authenticate()
+	return func(ctx *gin.Context) {
+		var request params
+		var err error
+		if ctx.Request.Method == http.MethodGet {
+			if err = ctx.ShouldBindQuery(&request); err == nil {
+				if variables := ctx.Query("variables"); variables != "" {
+					err = json.Unmarshal([]byte(variables), &request.Variables)
+				}
+			}
+		} else {
+			err = ctx.ShouldBindJSON(&request)
+		}
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+			return
+		}
+		ctx.Set(paramsKey, request)
+
+		if operation(request) != ast.OperationTypeMutation {
+			ctx.Next()
+			return
+		}
+		if ctx.Request.Method == http.MethodGet {
+			ctx.AbortWithStatusJSON(http.StatusMethodNotAllowed, gin.H{"error": "Mutations must be sent with POST"})
+			return
+		}
+		authenticate(ctx)
+	}
+}
+
+// operation returns the type of the operation request asks to run. Requests
+// that do not parse are left for the executor to report.
+func operation(request params) string {
+	document, err := parser.Parse(parser.ParseParams{Source: request.Query})
+	if err != nil {
+		return ""
+	}
+	for _, definition := range document.Definitions {
+		definition, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if request.OperationName == "" || (definition.Name != nil && definition.Name.Value == request.OperationName) {
+			return definition.Operation
+		}
+	}
+	return ""
+}
+
+// Serve executes the request read by Authenticate. Errors are reported in
+// the response body, as GraphQL clients expect.
+func (handler *Handler) Serve(ctx *gin.Context) {
+	request := ctx.MustGet(paramsKey).(params)
+	requestCtx := ctx.Request.Context()
+	result := gql.Do(gql.Params{
+		Schema:         handler.schema,
+		RequestString:  request.Query,
+		OperationName:  request.OperationName,
+		VariableValues: request.Variables,
+		Context:        withLoader(requestCtx, newRecipeLoader(handler.db.WithContext(requestCtx))),
+	})
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,140 @@
+package graphql_api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	jwt_auth "github.com/tolopsy/foodpro/api/server/middleware/authentication/jwt"
+)
+
+const (
+	deleteMutation = `mutation { deleteRecipe(id: "1") }`
+	readAndWrite   = `query Read { recipes { name } } mutation Write { deleteRecipe(id: "1") }`
+)
+
+func token(t *testing.T) string {
+	t.Helper()
+	claims := jwt_auth.Claims{
+		Username:         "alice",
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reached := false
+	engine := gin.New()
+	authenticate := (&Handler{}).Authenticate(jwt_auth.NewJWTAuth("secret", nil))
+	next := func(ctx *gin.Context) { reached = true }
+	engine.GET("/graphql", authenticate, next)
+	engine.POST("/graphql", authenticate, next)
+
+	tests := []struct {
+		name, method, query, operationName, token string
+		status                                    int
+		reached                                   bool
+	}{
+		{"query over GET", http.MethodGet, `{ recipes { name } }`, "", "", http.StatusOK, true},
+		{"query over POST", http.MethodPost, `{ recipes { name } }`, "", "", http.StatusOK, true},
+		{"mutation over GET", http.MethodGet, deleteMutation, "", token(t), http.StatusMethodNotAllowed, false},
+		{"mutation without credentials", http.MethodPost, deleteMutation, "", "", http.StatusUnauthorized, false},
+		{"mutation with a bad token", http.MethodPost, deleteMutation, "", "not-a-token", http.StatusUnauthorized, false},
+		{"mutation with credentials", http.MethodPost, deleteMutation, "", token(t), http.StatusOK, true},
+		{"mutation named among queries", http.MethodPost, readAndWrite, "Write", "", http.StatusUnauthorized, false},
+		{"query named among mutations", http.MethodPost, readAndWrite, "Read", "", http.StatusOK, true},
+	}
+	for _, test := range tests {
+		reached = false
+		var request *http.Request
+		if test.method == http.MethodGet {
+			request = httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(test.query), nil)
+		} else {
+			body, _ := json.Marshal(map[string]string{"query": test.query, "operationName": test.operationName})
+			request = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+			request.Header.Set("Content-Type", "application/json")
+		}
+		if test.token != "" {
+			request.Header.Set("Authorization", test.token)
+		}
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, request)
+		if recorder.Code != test.status || reached != test.reached {
+			t.Errorf("%s: status %d, reached the resolver %v; want %d, %v", test.name, recorder.Code, reached, test.status, test.reached)
+		}
+	}
+}
+
+// countingStore serves FetchRecipesByIDs from a map, recording each call.
+type countingStore struct {
+	persistence.DatabaseHandler
+	recipes map[string]persistence.Recipe
+	calls   [][]string
+}
+
+func (store *countingStore) WithContext(context.Context) persistence.DatabaseHandler {
+	return store
+}
+
+func (store *countingStore) FetchRecipesByIDs(ids []string) ([]persistence.Recipe, error) {
+	store.calls = append(store.calls, ids)
+	var recipes []persistence.Recipe
+	for _, id := range ids {
+		if recipe, ok := store.recipes[id]; ok {
+			recipes = append(recipes, recipe)
+		}
+	}
+	return recipes, nil
+}
+
+func TestRecipeLookupsAreBatched(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &countingStore{recipes: map[string]persistence.Recipe{
+		"1": {ID: "1", Name: "Stew"},
+		"2": {ID: "2", Name: "Curry"},
+	}}
+	handler, err := NewHandler(store, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	engine.POST("/graphql", handler.Authenticate(jwt_auth.NewJWTAuth("secret", nil)), handler.Serve)
+
+	query := `{ a: recipe(id: "1") { name } b: recipe(id: "2") { name } c: recipe(id: "3") { name } }`
+	body, _ := json.Marshal(map[string]string{"query": query})
+	request := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	var response struct {
+		Data   map[string]*struct{ Name string }
+		Errors []interface{}
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Errors) > 0 || response.Data["a"].Name != "Stew" || response.Data["b"].Name != "Curry" || response.Data["c"] != nil {
+		t.Errorf("response = %s", recorder.Body)
+	}
+	if want := [][]string{{"1", "2", "3"}}; !reflect.DeepEqual(store.calls, want) {
+		t.Errorf("FetchRecipesByIDs calls = %q, want %q", store.calls, want)
+	}
+}
@@ -0,0 +1,87 @@
+package graphql_api
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// recipeLoader batches recipe lookups made while one level of a query is
+// resolved. Each lookup only records its id and returns a thunk; the first
+// thunk to run fetches every recorded id with a single FetchRecipesByIDs
+// call. Results, and the error of a batch that failed, are kept for the
+// rest of the request.
+type recipeLoader struct {
+	db      persistence.DatabaseHandler
+	mutex   sync.Mutex
+	pending []string
+	loaded  map[string]*persistence.Recipe
+	failed  map[string]error
+}
+
+func newRecipeLoader(db persistence.DatabaseHandler) *recipeLoader {
+	return &recipeLoader{db: db, loaded: make(map[string]*persistence.Recipe), failed: make(map[string]error)}
+}
+
+// load returns a thunk resolving to the recipe with id, or nil when there is
+// no such recipe.
+func (loader *recipeLoader) load(id string) func() (interface{}, error) {
+	loader.mutex.Lock()
+	_, loaded := loader.loaded[id]
+	if _, failed := loader.failed[id]; !loaded && !failed {
+		loader.pending = append(loader.pending, id)
+	}
+	loader.mutex.Unlock()
+
+	return func() (interface{}, error) {
+		loader.flush()
+		loader.mutex.Lock()
+		defer loader.mutex.Unlock()
+		if err := loader.failed[id]; err != nil {
+			return nil, err
+		}
+		if recipe := loader.loaded[id]; recipe != nil {
+			return *recipe, nil
+		}
+		return nil, nil
+	}
+}
+
+// flush fetches every pending id. Ids that match no recipe are remembered
+// as missing so they are not asked for again. When the fetch fails, its
+// error is remembered for every id of the batch, so each thunk waiting on
+// one of them reports it rather than only the thunk that ran the fetch.
+func (loader *recipeLoader) flush() {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+	if len(loader.pending) == 0 {
+		return
+	}
+
+	ids := loader.pending
+	loader.pending = nil
+	recipes, err := loader.db.FetchRecipesByIDs(ids)
+	if err != nil {
+		for _, id := range ids {
+			loader.failed[id] = err
+		}
+		return
+	}
+	for _, id := range ids {
+		loader.loaded[id] = nil
+	}
+	for i := range recipes {
+		loader.loaded[recipes[i].IDString()] = &recipes[i]
+	}
+}
+
+type loaderKey struct{}
+
+func withLoader(ctx context.Context, loader *recipeLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, loader)
+}
+
+func loaderFrom(ctx context.Context) *recipeLoader {
+	return ctx.Value(loaderKey{}).(*recipeLoader)
+}
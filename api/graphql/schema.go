@@ -0,0 +1,326 @@
+package graphql_api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	gql "github.com/graphql-go/graphql"
+
+	"github.com/tolopsy/foodpro/api/logging"
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/blob"
+	"github.com/tolopsy/foodpro/api/persistence/cache"
+	"github.com/tolopsy/foodpro/api/persistence/db"
+)
+
+// ErrorRecipeModified is reported by updateRecipe and deleteRecipe when the
+// recipe is no longer at the version the client gave.
+var ErrorRecipeModified = errors.New("Recipe has been modified")
+
+var stringList = gql.NewList(gql.NewNonNull(gql.String))
+
+var ingredientType = gql.NewObject(gql.ObjectConfig{
+	Name: "Ingredient",
+	Fields: gql.Fields{
+		"quantity": &gql.Field{Type: gql.Float},
+		"unit":     &gql.Field{Type: gql.String},
+		"item":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"note":     &gql.Field{Type: gql.String},
+		"optional": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+		"text": &gql.Field{
+			Type:        gql.NewNonNull(gql.String),
+			Description: "The ingredient as one line, such as 2 1/2 cups onions, chopped.",
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return p.Source.(persistence.Ingredient).String(), nil
+			},
+		},
+	},
+})
+
+var imageType = gql.NewObject(gql.ObjectConfig{
+	Name: "RecipeImage",
+	Fields: gql.Fields{
+		"id":           &gql.Field{Type: gql.NewNonNull(gql.ID)},
+		"url":          &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"mediumUrl":    &gql.Field{Type: gql.String},
+		"thumbnailUrl": &gql.Field{Type: gql.String},
+		"contentType":  &gql.Field{Type: gql.String},
+		"width":        &gql.Field{Type: gql.Int},
+		"height":       &gql.Field{Type: gql.Int},
+	},
+})
+
+var recipeType = gql.NewObject(gql.ObjectConfig{
+	Name: "Recipe",
+	Fields: gql.Fields{
+		"id": &gql.Field{
+			Type: gql.NewNonNull(gql.ID),
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return p.Source.(persistence.Recipe).IDString(), nil
+			},
+		},
+		"name":          &gql.Field{Type: gql.NewNonNull(gql.String)},
+		"tags":          &gql.Field{Type: stringList},
+		"ingredients":   &gql.Field{Type: gql.NewList(gql.NewNonNull(ingredientType))},
+		"instructions":  &gql.Field{Type: stringList},
+		"servings":      &gql.Field{Type: gql.Int},
+		"allergens":     &gql.Field{Type: stringList},
+		"diets":         &gql.Field{Type: stringList},
+		"images":        &gql.Field{Type: gql.NewList(gql.NewNonNull(imageType))},
+		"publishedAt":   &gql.Field{Type: gql.DateTime},
+		"version":       &gql.Field{Type: gql.Int, Description: "Pass to updateRecipe and deleteRecipe to guard against lost updates."},
+		"ratingAverage": &gql.Field{Type: gql.Float},
+		"ratingCount":   &gql.Field{Type: gql.Int},
+		"favoriteCount": &gql.Field{Type: gql.Int},
+	},
+})
+
+var recipeSortType = gql.NewEnum(gql.EnumConfig{
+	Name: "RecipeSort",
+	Values: gql.EnumValueConfigMap{
+		"RATING": &gql.EnumValueConfig{Value: "rating", Description: "Best rated first; unrated recipes come last."},
+	},
+})
+
+var ingredientInputType = gql.NewInputObject(gql.InputObjectConfig{
+	Name:        "IngredientInput",
+	Description: "An ingredient, given either as one line of text, which is parsed, or as separate fields.",
+	Fields: gql.InputObjectConfigFieldMap{
+		"text":     &gql.InputObjectFieldConfig{Type: gql.String},
+		"quantity": &gql.InputObjectFieldConfig{Type: gql.Float},
+		"unit":     &gql.InputObjectFieldConfig{Type: gql.String},
+		"item":     &gql.InputObjectFieldConfig{Type: gql.String},
+		"note":     &gql.InputObjectFieldConfig{Type: gql.String},
+		"optional": &gql.InputObjectFieldConfig{Type: gql.Boolean},
+	},
+})
+
+var recipeInputType = gql.NewInputObject(gql.InputObjectConfig{
+	Name: "RecipeInput",
+	Fields: gql.InputObjectConfigFieldMap{
+		"name":         &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+		"tags":         &gql.InputObjectFieldConfig{Type: stringList},
+		"ingredients":  &gql.InputObjectFieldConfig{Type: gql.NewList(gql.NewNonNull(ingredientInputType))},
+		"instructions": &gql.InputObjectFieldConfig{Type: stringList},
+		"servings":     &gql.InputObjectFieldConfig{Type: gql.Int},
+		"publishedAt":  &gql.InputObjectFieldConfig{Type: gql.DateTime},
+	},
+})
+
+// resolver answers the schema's fields from the same stores as the REST
+// handlers, bound to the context of the request being executed.
+type resolver struct {
+	db     persistence.DatabaseHandler
+	cache  persistence.CacheHandler
+	blobs  persistence.BlobStore
+	logger *slog.Logger
+}
+
+func newSchema(resolver *resolver) (gql.Schema, error) {
+	recipeList := gql.NewNonNull(gql.NewList(gql.NewNonNull(recipeType)))
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"recipes": &gql.Field{
+				Type:        recipeList,
+				Description: "Every recipe, or only those with the given ids in the order asked for.",
+				Args: gql.FieldConfigArgument{
+					"ids":  &gql.ArgumentConfig{Type: gql.NewList(gql.NewNonNull(gql.ID))},
+					"sort": &gql.ArgumentConfig{Type: recipeSortType},
+				},
+				Resolve: resolver.recipes,
+			},
+			"recipe": &gql.Field{
+				Type:    recipeType,
+				Args:    gql.FieldConfigArgument{"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)}},
+				Resolve: resolver.recipe,
+			},
+			"searchRecipes": &gql.Field{
+				Type:        recipeList,
+				Description: "Recipes carrying tag, suitable for every one of diets and free of every one of allergenFree.",
+				Args: gql.FieldConfigArgument{
+					"tag":          &gql.ArgumentConfig{Type: gql.String},
+					"diets":        &gql.ArgumentConfig{Type: stringList},
+					"allergenFree": &gql.ArgumentConfig{Type: stringList},
+				},
+				Resolve: resolver.searchRecipes,
+			},
+		},
+	})
+
+	version := &gql.ArgumentConfig{
+		Type:        gql.Int,
+		Description: "Fail unless the recipe is still at this version.",
+	}
+	mutation := gql.NewObject(gql.ObjectConfig{
+		Name: "Mutation",
+		Fields: gql.Fields{
+			"createRecipe": &gql.Field{
+				Type:    gql.NewNonNull(recipeType),
+				Args:    gql.FieldConfigArgument{"input": &gql.ArgumentConfig{Type: gql.NewNonNull(recipeInputType)}},
+				Resolve: resolver.createRecipe,
+			},
+			"updateRecipe": &gql.Field{
+				Type: gql.NewNonNull(recipeType),
+				Args: gql.FieldConfigArgument{
+					"id":      &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+					"input":   &gql.ArgumentConfig{Type: gql.NewNonNull(recipeInputType)},
+					"version": version,
+				},
+				Resolve: resolver.updateRecipe,
+			},
+			"deleteRecipe": &gql.Field{
+				Type: gql.NewNonNull(gql.Boolean),
+				Args: gql.FieldConfigArgument{
+					"id":      &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)},
+					"version": version,
+				},
+				Resolve: resolver.deleteRecipe,
+			},
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// stores binds the database and cache to the request's context.
+func (resolver *resolver) stores(ctx context.Context) (persistence.DatabaseHandler, persistence.CacheHandler) {
+	return resolver.db.WithContext(ctx), resolver.cache.WithContext(ctx)
+}
+
+func (resolver *resolver) recipes(p gql.ResolveParams) (interface{}, error) {
+	if ids, ok := p.Args["ids"].([]interface{}); ok {
+		return resolver.recipesByIDs(p.Context, ids), nil
+	}
+
+	database, recipeCache := resolver.stores(p.Context)
+	recipes, err := cache.Recipes(database, recipeCache, logging.FromContext(p.Context, resolver.logger))
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Args["sort"] == "rating" {
+		persistence.SortByRating(recipes)
+	}
+	return recipes, nil
+}
+
+// recipesByIDs queues every id with the request's loader, so lists asked
+// for side by side are fetched together, and skips ids with no recipe.
+func (resolver *resolver) recipesByIDs(ctx context.Context, ids []interface{}) func() (interface{}, error) {
+	loader := loaderFrom(ctx)
+	thunks := make([]func() (interface{}, error), len(ids))
+	for i, id := range ids {
+		thunks[i] = loader.load(id.(string))
+	}
+	return func() (interface{}, error) {
+		recipes := make([]persistence.Recipe, 0, len(thunks))
+		for _, thunk := range thunks {
+			recipe, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			if recipe != nil {
+				recipes = append(recipes, recipe.(persistence.Recipe))
+			}
+		}
+		return recipes, nil
+	}
+}
+
+func (resolver *resolver) recipe(p gql.ResolveParams) (interface{}, error) {
+	return loaderFrom(p.Context).load(p.Args["id"].(string)), nil
+}
+
+func (resolver *resolver) searchRecipes(p gql.ResolveParams) (interface{}, error) {
+	database, _ := resolver.stores(p.Context)
+	tag, _ := p.Args["tag"].(string)
+	return database.SearchRecipes(persistence.RecipeFilter{
+		Tag:              tag,
+		Diets:            stringArgs(p.Args["diets"]),
+		ExcludeAllergens: stringArgs(p.Args["allergenFree"]),
+	})
+}
+
+func (resolver *resolver) createRecipe(p gql.ResolveParams) (interface{}, error) {
+	database, recipeCache := resolver.stores(p.Context)
+	recipe := recipeFromInput(p.Args["input"].(map[string]interface{}))
+	if err := database.AddRecipe(&recipe); err != nil {
+		return nil, err
+	}
+	recipeCache.ClearRecipes()
+	return recipe, nil
+}
+
+func (resolver *resolver) updateRecipe(p gql.ResolveParams) (interface{}, error) {
+	database, recipeCache := resolver.stores(p.Context)
+	id := p.Args["id"].(string)
+	recipe := recipeFromInput(p.Args["input"].(map[string]interface{}))
+	if err := database.UpdateRecipe(id, recipe, expectedVersion(p.Args)); err == db.ErrorVersionMismatch {
+		return nil, ErrorRecipeModified
+	} else if err != nil {
+		return nil, err
+	}
+	recipeCache.ClearRecipes()
+	return database.GetRecipe(id)
+}
+
+func (resolver *resolver) deleteRecipe(p gql.ResolveParams) (interface{}, error) {
+	database, recipeCache := resolver.stores(p.Context)
+	images, err := database.DeleteRecipe(p.Args["id"].(string), expectedVersion(p.Args))
+	blob.DeleteImages(resolver.blobs, images)
+	if err == db.ErrorVersionMismatch {
+		return nil, ErrorRecipeModified
+	} else if err != nil {
+		return nil, err
+	}
+	recipeCache.ClearRecipes()
+	return true, nil
+}
+
+// expectedVersion returns the version a write is conditioned on, or
+// persistence.AnyVersion when the client gave none.
+func expectedVersion(args map[string]interface{}) int64 {
+	if version, ok := args["version"].(int); ok {
+		return int64(version)
+	}
+	return persistence.AnyVersion
+}
+
+func stringArgs(value interface{}) []string {
+	items, _ := value.([]interface{})
+	var values []string
+	for _, item := range items {
+		values = append(values, item.(string))
+	}
+	return values
+}
+
+func recipeFromInput(input map[string]interface{}) persistence.Recipe {
+	recipe := persistence.Recipe{
+		Name:         input["name"].(string),
+		Tags:         stringArgs(input["tags"]),
+		Instructions: stringArgs(input["instructions"]),
+	}
+	recipe.Servings, _ = input["servings"].(int)
+	recipe.PublishedAt, _ = input["publishedAt"].(time.Time)
+
+	ingredients, _ := input["ingredients"].([]interface{})
+	for _, value := range ingredients {
+		fields := value.(map[string]interface{})
+		if text, ok := fields["text"].(string); ok {
+			recipe.Ingredients = append(recipe.Ingredients, persistence.ParseIngredient(text))
+			continue
+		}
+		var ingredient persistence.Ingredient
+		ingredient.Quantity, _ = fields["quantity"].(float64)
+		ingredient.Unit, _ = fields["unit"].(string)
+		ingredient.Item, _ = fields["item"].(string)
+		ingredient.Note, _ = fields["note"].(string)
+		ingredient.Optional, _ = fields["optional"].(bool)
+		recipe.Ingredients = append(recipe.Ingredients, ingredient)
+	}
+	return recipe
+}
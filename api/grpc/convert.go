@@ -0,0 +1,111 @@
+package grpc_api
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/tolopsy/foodpro/api/grpc/recipepb"
+	"github.com/tolopsy/foodpro/api/nutrition"
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+func toRecipe(recipe persistence.Recipe) *recipepb.Recipe {
+	message := &recipepb.Recipe{
+		Id:            recipe.IDString(),
+		Name:          recipe.Name,
+		Tags:          recipe.Tags,
+		Instructions:  recipe.Instructions,
+		Servings:      int32(recipe.Servings),
+		Allergens:     recipe.Allergens,
+		Diets:         recipe.Diets,
+		Version:       recipe.Version,
+		RatingAverage: recipe.RatingAverage,
+		RatingCount:   int32(recipe.RatingCount),
+		FavoriteCount: int32(recipe.FavoriteCount),
+	}
+	if !recipe.PublishedAt.IsZero() {
+		message.PublishedAt = timestamppb.New(recipe.PublishedAt)
+	}
+	for _, ingredient := range recipe.Ingredients {
+		message.Ingredients = append(message.Ingredients, &recipepb.Ingredient{
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+			Item:     ingredient.Item,
+			Note:     ingredient.Note,
+			Optional: ingredient.Optional,
+			Text:     ingredient.String(),
+		})
+	}
+	for _, image := range recipe.Images {
+		message.Images = append(message.Images, &recipepb.RecipeImage{
+			Id:           image.ID,
+			Url:          image.URL,
+			MediumUrl:    image.MediumURL,
+			ThumbnailUrl: image.ThumbnailURL,
+			ContentType:  image.ContentType,
+			Width:        int32(image.Width),
+			Height:       int32(image.Height),
+		})
+	}
+	return message
+}
+
+func toRecipes(recipes []persistence.Recipe) []*recipepb.Recipe {
+	messages := make([]*recipepb.Recipe, len(recipes))
+	for i, recipe := range recipes {
+		messages[i] = toRecipe(recipe)
+	}
+	return messages
+}
+
+// fromInput builds the recipe a client asked to write. Ingredients given as
+// text are parsed the same way as in the REST API.
+func fromInput(input *recipepb.RecipeInput) persistence.Recipe {
+	recipe := persistence.Recipe{
+		Name:         input.GetName(),
+		Tags:         input.GetTags(),
+		Instructions: input.GetInstructions(),
+		Servings:     int(input.GetServings()),
+	}
+	if input.GetPublishedAt() != nil {
+		recipe.PublishedAt = input.GetPublishedAt().AsTime()
+	}
+	for _, ingredient := range input.GetIngredients() {
+		if ingredient.GetText() != "" {
+			recipe.Ingredients = append(recipe.Ingredients, persistence.ParseIngredient(ingredient.GetText()))
+			continue
+		}
+		recipe.Ingredients = append(recipe.Ingredients, persistence.Ingredient{
+			Quantity: ingredient.GetQuantity(),
+			Unit:     ingredient.GetUnit(),
+			Item:     ingredient.GetItem(),
+			Note:     ingredient.GetNote(),
+			Optional: ingredient.GetOptional(),
+		})
+	}
+	return recipe
+}
+
+func toNutrients(nutrients nutrition.Nutrients) *recipepb.Nutrients {
+	return &recipepb.Nutrients{
+		Calories:  nutrients.Calories,
+		Protein:   nutrients.Protein,
+		Fat:       nutrients.Fat,
+		Carbs:     nutrients.Carbs,
+		Fiber:     nutrients.Fiber,
+		Sugar:     nutrients.Sugar,
+		Sodium:    nutrients.Sodium,
+		Calcium:   nutrients.Calcium,
+		Iron:      nutrients.Iron,
+		Potassium: nutrients.Potassium,
+		VitaminC:  nutrients.VitaminC,
+	}
+}
+
+func toNutritionReport(report nutrition.Report) *recipepb.NutritionReport {
+	return &recipepb.NutritionReport{
+		Servings:   int32(report.Servings),
+		Total:      toNutrients(report.Total),
+		PerServing: toNutrients(report.PerServing),
+		Unmatched:  report.Unmatched,
+	}
+}
@@ -0,0 +1,466 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: recipe.proto
+
+package recipepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RecipeService_ListRecipes_FullMethodName         = "/foodpro.recipe.v1.RecipeService/ListRecipes"
+	RecipeService_StreamRecipes_FullMethodName       = "/foodpro.recipe.v1.RecipeService/StreamRecipes"
+	RecipeService_GetRecipe_FullMethodName           = "/foodpro.recipe.v1.RecipeService/GetRecipe"
+	RecipeService_GetRecipeNutrition_FullMethodName  = "/foodpro.recipe.v1.RecipeService/GetRecipeNutrition"
+	RecipeService_SearchRecipes_FullMethodName       = "/foodpro.recipe.v1.RecipeService/SearchRecipes"
+	RecipeService_StreamSearchRecipes_FullMethodName = "/foodpro.recipe.v1.RecipeService/StreamSearchRecipes"
+	RecipeService_CreateRecipe_FullMethodName        = "/foodpro.recipe.v1.RecipeService/CreateRecipe"
+	RecipeService_UpdateRecipe_FullMethodName        = "/foodpro.recipe.v1.RecipeService/UpdateRecipe"
+	RecipeService_DeleteRecipe_FullMethodName        = "/foodpro.recipe.v1.RecipeService/DeleteRecipe"
+)
+
+// RecipeServiceClient is the client API for RecipeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RecipeServiceClient interface {
+	ListRecipes(ctx context.Context, in *ListRecipesRequest, opts ...grpc.CallOption) (*ListRecipesResponse, error)
+	// StreamRecipes sends the recipes ListRecipes would return one at a time.
+	StreamRecipes(ctx context.Context, in *ListRecipesRequest, opts ...grpc.CallOption) (RecipeService_StreamRecipesClient, error)
+	GetRecipe(ctx context.Context, in *GetRecipeRequest, opts ...grpc.CallOption) (*Recipe, error)
+	GetRecipeNutrition(ctx context.Context, in *GetRecipeNutritionRequest, opts ...grpc.CallOption) (*NutritionReport, error)
+	SearchRecipes(ctx context.Context, in *SearchRecipesRequest, opts ...grpc.CallOption) (*SearchRecipesResponse, error)
+	// StreamSearchRecipes sends the recipes SearchRecipes would return one at
+	// a time.
+	StreamSearchRecipes(ctx context.Context, in *SearchRecipesRequest, opts ...grpc.CallOption) (RecipeService_StreamSearchRecipesClient, error)
+	CreateRecipe(ctx context.Context, in *CreateRecipeRequest, opts ...grpc.CallOption) (*Recipe, error)
+	UpdateRecipe(ctx context.Context, in *UpdateRecipeRequest, opts ...grpc.CallOption) (*Recipe, error)
+	DeleteRecipe(ctx context.Context, in *DeleteRecipeRequest, opts ...grpc.CallOption) (*DeleteRecipeResponse, error)
+}
+
+type recipeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecipeServiceClient(cc grpc.ClientConnInterface) RecipeServiceClient {
+	return &recipeServiceClient{cc}
+}
+
+func (c *recipeServiceClient) ListRecipes(ctx context.Context, in *ListRecipesRequest, opts ...grpc.CallOption) (*ListRecipesResponse, error) {
+	out := new(ListRecipesResponse)
+	err := c.cc.Invoke(ctx, RecipeService_ListRecipes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) StreamRecipes(ctx context.Context, in *ListRecipesRequest, opts ...grpc.CallOption) (RecipeService_StreamRecipesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RecipeService_ServiceDesc.Streams[0], RecipeService_StreamRecipes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &recipeServiceStreamRecipesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RecipeService_StreamRecipesClient interface {
+	Recv() (*Recipe, error)
+	grpc.ClientStream
+}
+
+type recipeServiceStreamRecipesClient struct {
+	grpc.ClientStream
+}
+
+func (x *recipeServiceStreamRecipesClient) Recv() (*Recipe, error) {
+	m := new(Recipe)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *recipeServiceClient) GetRecipe(ctx context.Context, in *GetRecipeRequest, opts ...grpc.CallOption) (*Recipe, error) {
+	out := new(Recipe)
+	err := c.cc.Invoke(ctx, RecipeService_GetRecipe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) GetRecipeNutrition(ctx context.Context, in *GetRecipeNutritionRequest, opts ...grpc.CallOption) (*NutritionReport, error) {
+	out := new(NutritionReport)
+	err := c.cc.Invoke(ctx, RecipeService_GetRecipeNutrition_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) SearchRecipes(ctx context.Context, in *SearchRecipesRequest, opts ...grpc.CallOption) (*SearchRecipesResponse, error) {
+	out := new(SearchRecipesResponse)
+	err := c.cc.Invoke(ctx, RecipeService_SearchRecipes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) StreamSearchRecipes(ctx context.Context, in *SearchRecipesRequest, opts ...grpc.CallOption) (RecipeService_StreamSearchRecipesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RecipeService_ServiceDesc.Streams[1], RecipeService_StreamSearchRecipes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &recipeServiceStreamSearchRecipesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RecipeService_StreamSearchRecipesClient interface {
+	Recv() (*Recipe, error)
+	grpc.ClientStream
+}
+
+type recipeServiceStreamSearchRecipesClient struct {
+	grpc.ClientStream
+}
+
+func (x *recipeServiceStreamSearchRecipesClient) Recv() (*Recipe, error) {
+	m := new(Recipe)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *recipeServiceClient) CreateRecipe(ctx context.Context, in *CreateRecipeRequest, opts ...grpc.CallOption) (*Recipe, error) {
+	out := new(Recipe)
+	err := c.cc.Invoke(ctx, RecipeService_CreateRecipe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) UpdateRecipe(ctx context.Context, in *UpdateRecipeRequest, opts ...grpc.CallOption) (*Recipe, error) {
+	out := new(Recipe)
+	err := c.cc.Invoke(ctx, RecipeService_UpdateRecipe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recipeServiceClient) DeleteRecipe(ctx context.Context, in *DeleteRecipeRequest, opts ...grpc.CallOption) (*DeleteRecipeResponse, error) {
+	out := new(DeleteRecipeResponse)
+	err := c.cc.Invoke(ctx, RecipeService_DeleteRecipe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecipeServiceServer is the server API for RecipeService service.
+// All implementations must embed UnimplementedRecipeServiceServer
+// for forward compatibility
+type RecipeServiceServer interface {
+	ListRecipes(context.Context, *ListRecipesRequest) (*ListRecipesResponse, error)
+	// StreamRecipes sends the recipes ListRecipes would return one at a time.
+	StreamRecipes(*ListRecipesRequest, RecipeService_StreamRecipesServer) error
+	GetRecipe(context.Context, *GetRecipeRequest) (*Recipe, error)
+	GetRecipeNutrition(context.Context, *GetRecipeNutritionRequest) (*NutritionReport, error)
+	SearchRecipes(context.Context, *SearchRecipesRequest) (*SearchRecipesResponse, error)
+	// StreamSearchRecipes sends the recipes SearchRecipes would return one at
+	// a time.
+	StreamSearchRecipes(*SearchRecipesRequest, RecipeService_StreamSearchRecipesServer) error
+	CreateRecipe(context.Context, *CreateRecipeRequest) (*Recipe, error)
+	UpdateRecipe(context.Context, *UpdateRecipeRequest) (*Recipe, error)
+	DeleteRecipe(context.Context, *DeleteRecipeRequest) (*DeleteRecipeResponse, error)
+	mustEmbedUnimplementedRecipeServiceServer()
+}
+
+// UnimplementedRecipeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRecipeServiceServer struct {
+}
+
+func (UnimplementedRecipeServiceServer) ListRecipes(context.Context, *ListRecipesRequest) (*ListRecipesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecipes not implemented")
+}
+func (UnimplementedRecipeServiceServer) StreamRecipes(*ListRecipesRequest, RecipeService_StreamRecipesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRecipes not implemented")
+}
+func (UnimplementedRecipeServiceServer) GetRecipe(context.Context, *GetRecipeRequest) (*Recipe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) GetRecipeNutrition(context.Context, *GetRecipeNutritionRequest) (*NutritionReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecipeNutrition not implemented")
+}
+func (UnimplementedRecipeServiceServer) SearchRecipes(context.Context, *SearchRecipesRequest) (*SearchRecipesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchRecipes not implemented")
+}
+func (UnimplementedRecipeServiceServer) StreamSearchRecipes(*SearchRecipesRequest, RecipeService_StreamSearchRecipesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSearchRecipes not implemented")
+}
+func (UnimplementedRecipeServiceServer) CreateRecipe(context.Context, *CreateRecipeRequest) (*Recipe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) UpdateRecipe(context.Context, *UpdateRecipeRequest) (*Recipe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) DeleteRecipe(context.Context, *DeleteRecipeRequest) (*DeleteRecipeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecipe not implemented")
+}
+func (UnimplementedRecipeServiceServer) mustEmbedUnimplementedRecipeServiceServer() {}
+
+// UnsafeRecipeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecipeServiceServer will
+// result in compilation errors.
+type UnsafeRecipeServiceServer interface {
+	mustEmbedUnimplementedRecipeServiceServer()
+}
+
+func RegisterRecipeServiceServer(s grpc.ServiceRegistrar, srv RecipeServiceServer) {
+	s.RegisterService(&RecipeService_ServiceDesc, srv)
+}
+
+func _RecipeService_ListRecipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecipesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).ListRecipes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_ListRecipes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).ListRecipes(ctx, req.(*ListRecipesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_StreamRecipes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRecipesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecipeServiceServer).StreamRecipes(m, &recipeServiceStreamRecipesServer{stream})
+}
+
+type RecipeService_StreamRecipesServer interface {
+	Send(*Recipe) error
+	grpc.ServerStream
+}
+
+type recipeServiceStreamRecipesServer struct {
+	grpc.ServerStream
+}
+
+func (x *recipeServiceStreamRecipesServer) Send(m *Recipe) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RecipeService_GetRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecipeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).GetRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_GetRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).GetRecipe(ctx, req.(*GetRecipeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_GetRecipeNutrition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecipeNutritionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).GetRecipeNutrition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_GetRecipeNutrition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).GetRecipeNutrition(ctx, req.(*GetRecipeNutritionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_SearchRecipes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRecipesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).SearchRecipes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_SearchRecipes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).SearchRecipes(ctx, req.(*SearchRecipesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_StreamSearchRecipes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchRecipesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RecipeServiceServer).StreamSearchRecipes(m, &recipeServiceStreamSearchRecipesServer{stream})
+}
+
+type RecipeService_StreamSearchRecipesServer interface {
+	Send(*Recipe) error
+	grpc.ServerStream
+}
+
+type recipeServiceStreamSearchRecipesServer struct {
+	grpc.ServerStream
+}
+
+func (x *recipeServiceStreamSearchRecipesServer) Send(m *Recipe) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RecipeService_CreateRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRecipeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).CreateRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_CreateRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).CreateRecipe(ctx, req.(*CreateRecipeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_UpdateRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRecipeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).UpdateRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_UpdateRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).UpdateRecipe(ctx, req.(*UpdateRecipeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecipeService_DeleteRecipe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRecipeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecipeServiceServer).DeleteRecipe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecipeService_DeleteRecipe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecipeServiceServer).DeleteRecipe(ctx, req.(*DeleteRecipeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RecipeService_ServiceDesc is the grpc.ServiceDesc for RecipeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RecipeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "foodpro.recipe.v1.RecipeService",
+	HandlerType: (*RecipeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRecipes",
+			Handler:    _RecipeService_ListRecipes_Handler,
+		},
+		{
+			MethodName: "GetRecipe",
+			Handler:    _RecipeService_GetRecipe_Handler,
+		},
+		{
+			MethodName: "GetRecipeNutrition",
+			Handler:    _RecipeService_GetRecipeNutrition_Handler,
+		},
+		{
+			MethodName: "SearchRecipes",
+			Handler:    _RecipeService_SearchRecipes_Handler,
+		},
+		{
+			MethodName: "CreateRecipe",
+			Handler:    _RecipeService_CreateRecipe_Handler,
+		},
+		{
+			MethodName: "UpdateRecipe",
+			Handler:    _RecipeService_UpdateRecipe_Handler,
+		},
+		{
+			MethodName: "DeleteRecipe",
+			Handler:    _RecipeService_DeleteRecipe_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRecipes",
+			Handler:       _RecipeService_StreamRecipes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamSearchRecipes",
+			Handler:       _RecipeService_StreamSearchRecipes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "recipe.proto",
+}
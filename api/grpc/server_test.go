@@ -0,0 +1,141 @@
+package grpc_api
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tolopsy/foodpro/api/grpc/recipepb"
+	jwt_auth "github.com/tolopsy/foodpro/api/server/middleware/authentication/jwt"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func token(t *testing.T) string {
+	t.Helper()
+	claims := jwt_auth.Claims{
+		Username:         "alice",
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// methods returns the full name of every method of RecipeService.
+func methods() []string {
+	var names []string
+	prefix := "/" + recipepb.RecipeService_ServiceDesc.ServiceName + "/"
+	for _, method := range recipepb.RecipeService_ServiceDesc.Methods {
+		names = append(names, prefix+method.MethodName)
+	}
+	for _, stream := range recipepb.RecipeService_ServiceDesc.Streams {
+		names = append(names, prefix+stream.StreamName)
+	}
+	return names
+}
+
+func TestProtected(t *testing.T) {
+	writes := map[string]bool{"CreateRecipe": true, "UpdateRecipe": true, "DeleteRecipe": true}
+	prefix := "/" + recipepb.RecipeService_ServiceDesc.ServiceName + "/"
+	for _, method := range methods() {
+		if want := writes[method[len(prefix):]]; protected[method] != want {
+			t.Errorf("protected[%s] = %v, want %v", method, protected[method], want)
+		}
+	}
+}
+
+func TestInterceptorUnary(t *testing.T) {
+	interceptor := &interceptor{auth: jwt_auth.NewJWTAuth("secret", nil), logger: discard}
+	signedIn := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", token(t)))
+	badToken := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "not-a-token"))
+
+	for _, method := range methods() {
+		for _, test := range []struct {
+			name string
+			ctx  context.Context
+			want codes.Code
+		}{
+			{"without credentials", context.Background(), codes.Unauthenticated},
+			{"with a bad token", badToken, codes.Unauthenticated},
+			{"with credentials", signedIn, codes.OK},
+		} {
+			if !protected[method] {
+				test.want = codes.OK
+			}
+			called := false
+			_, err := interceptor.unary(test.ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+				func(ctx context.Context, request interface{}) (interface{}, error) {
+					called = true
+					return nil, nil
+				})
+			if code := status.Code(err); code != test.want || called != (test.want == codes.OK) {
+				t.Errorf("%s %s: code %s, handler called %v; want %s", method, test.name, code, called, test.want)
+			}
+		}
+	}
+}
+
+func TestInterceptorRecoversPanics(t *testing.T) {
+	interceptor := &interceptor{auth: jwt_auth.NewJWTAuth("secret", nil), logger: discard}
+	_, err := interceptor.unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: recipepb.RecipeService_GetRecipe_FullMethodName},
+		func(ctx context.Context, request interface{}) (interface{}, error) {
+			panic("boom")
+		})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("error = %v, want an Internal status", err)
+	}
+}
+
+// The writes are refused before the service, which has no stores here,
+// is reached.
+func TestServerRejectsWritesWithoutCredentials(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(NewRecipeService(nil, nil, nil, nil, discard), jwt_auth.NewJWTAuth("secret", nil), discard)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := recipepb.NewRecipeServiceClient(conn)
+
+	calls := map[string]func() error{
+		"CreateRecipe": func() error {
+			_, err := client.CreateRecipe(ctx, &recipepb.CreateRecipeRequest{Recipe: &recipepb.RecipeInput{Name: "Stew"}})
+			return err
+		},
+		"UpdateRecipe": func() error {
+			_, err := client.UpdateRecipe(ctx, &recipepb.UpdateRecipeRequest{Id: "1", Recipe: &recipepb.RecipeInput{Name: "Stew"}})
+			return err
+		},
+		"DeleteRecipe": func() error {
+			_, err := client.DeleteRecipe(ctx, &recipepb.DeleteRecipeRequest{Id: "1"})
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s without credentials = %v, want Unauthenticated", name, err)
+		}
+	}
+}
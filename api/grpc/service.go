@@ -0,0 +1,204 @@
+package grpc_api
+
+import (
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tolopsy/foodpro/api/grpc/recipepb"
+	"github.com/tolopsy/foodpro/api/logging"
+	"github.com/tolopsy/foodpro/api/nutrition"
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/blob"
+	"github.com/tolopsy/foodpro/api/persistence/cache"
+	"github.com/tolopsy/foodpro/api/persistence/db"
+	"github.com/tolopsy/foodpro/api/units"
+)
+
+// RecipeService serves recipepb.RecipeService from the same stores as the
+// REST handlers, binding them to each call's context.
+type RecipeService struct {
+	recipepb.UnimplementedRecipeServiceServer
+	db     persistence.DatabaseHandler
+	cache  persistence.CacheHandler
+	blobs  persistence.BlobStore
+	foods  *nutrition.Table
+	logger *slog.Logger
+}
+
+func NewRecipeService(db persistence.DatabaseHandler, cache persistence.CacheHandler, blobs persistence.BlobStore, foods *nutrition.Table, logger *slog.Logger) *RecipeService {
+	return &RecipeService{
+		db:     db,
+		cache:  cache,
+		blobs:  blobs,
+		foods:  foods,
+		logger: logger,
+	}
+}
+
+// listRecipes reads every recipe from the cache, falling back to the
+// database and refilling the cache on a miss.
+func (service *RecipeService) listRecipes(ctx context.Context, request *recipepb.ListRecipesRequest) ([]persistence.Recipe, error) {
+	recipes, err := cache.Recipes(service.db.WithContext(ctx), service.cache.WithContext(ctx), logging.FromContext(ctx, service.logger))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if request.GetSort() == recipepb.ListRecipesRequest_SORT_RATING {
+		persistence.SortByRating(recipes)
+	}
+	return recipes, nil
+}
+
+func (service *RecipeService) ListRecipes(ctx context.Context, request *recipepb.ListRecipesRequest) (*recipepb.ListRecipesResponse, error) {
+	recipes, err := service.listRecipes(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return &recipepb.ListRecipesResponse{Recipes: toRecipes(recipes)}, nil
+}
+
+// StreamRecipes reads recipes straight from a database cursor, so the
+// whole collection is never held in memory.
+func (service *RecipeService) StreamRecipes(request *recipepb.ListRecipesRequest, stream recipepb.RecipeService_StreamRecipesServer) error {
+	filter := persistence.RecipeFilter{ByRating: request.GetSort() == recipepb.ListRecipesRequest_SORT_RATING}
+	return service.send(stream, filter)
+}
+
+// getRecipe fetches one recipe, reporting ids that are malformed or match
+// no recipe as not found.
+func (service *RecipeService) getRecipe(ctx context.Context, id string) (persistence.Recipe, error) {
+	recipes, err := service.db.WithContext(ctx).FetchRecipesByIDs([]string{id})
+	if err != nil {
+		return persistence.Recipe{}, status.Error(codes.Internal, err.Error())
+	}
+	if len(recipes) == 0 {
+		return persistence.Recipe{}, status.Error(codes.NotFound, db.ErrorNotFound.Error())
+	}
+	return recipes[0], nil
+}
+
+func (service *RecipeService) GetRecipe(ctx context.Context, request *recipepb.GetRecipeRequest) (*recipepb.Recipe, error) {
+	if request.GetServings() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "servings must be a positive whole number")
+	}
+	system := units.System(request.GetUnits())
+	if system != "" && system != units.Metric && system != units.Imperial {
+		return nil, status.Error(codes.InvalidArgument, "units must be either metric or imperial")
+	}
+
+	recipe, err := service.getRecipe(ctx, request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if request.GetServings() > 0 || system != "" {
+		if recipe, err = recipe.Scale(int(request.GetServings()), system); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+	return toRecipe(recipe), nil
+}
+
+func (service *RecipeService) GetRecipeNutrition(ctx context.Context, request *recipepb.GetRecipeNutritionRequest) (*recipepb.NutritionReport, error) {
+	recipe, err := service.getRecipe(ctx, request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return toNutritionReport(service.foods.Calculate(recipe)), nil
+}
+
+func searchFilter(request *recipepb.SearchRecipesRequest) persistence.RecipeFilter {
+	return persistence.RecipeFilter{
+		Tag:              request.GetTag(),
+		Diets:            request.GetDiets(),
+		ExcludeAllergens: request.GetAllergenFree(),
+	}
+}
+
+func (service *RecipeService) SearchRecipes(ctx context.Context, request *recipepb.SearchRecipesRequest) (*recipepb.SearchRecipesResponse, error) {
+	recipes, err := service.db.WithContext(ctx).SearchRecipes(searchFilter(request))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &recipepb.SearchRecipesResponse{Recipes: toRecipes(recipes)}, nil
+}
+
+func (service *RecipeService) StreamSearchRecipes(request *recipepb.SearchRecipesRequest, stream recipepb.RecipeService_StreamSearchRecipesServer) error {
+	return service.send(stream, searchFilter(request))
+}
+
+func (service *RecipeService) CreateRecipe(ctx context.Context, request *recipepb.CreateRecipeRequest) (*recipepb.Recipe, error) {
+	if request.GetRecipe().GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "recipe.name is required")
+	}
+	recipe := fromInput(request.GetRecipe())
+	if err := service.db.WithContext(ctx).AddRecipe(&recipe); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	service.cache.WithContext(ctx).ClearRecipes()
+	return toRecipe(recipe), nil
+}
+
+func (service *RecipeService) UpdateRecipe(ctx context.Context, request *recipepb.UpdateRecipeRequest) (*recipepb.Recipe, error) {
+	if request.GetRecipe() == nil {
+		return nil, status.Error(codes.InvalidArgument, "recipe is required")
+	}
+	database := service.db.WithContext(ctx)
+	err := database.UpdateRecipe(request.GetId(), fromInput(request.GetRecipe()), expectedVersion(request.Version))
+	if err != nil {
+		return nil, writeError(err)
+	}
+	service.cache.WithContext(ctx).ClearRecipes()
+	return service.GetRecipe(ctx, &recipepb.GetRecipeRequest{Id: request.GetId()})
+}
+
+func (service *RecipeService) DeleteRecipe(ctx context.Context, request *recipepb.DeleteRecipeRequest) (*recipepb.DeleteRecipeResponse, error) {
+	images, err := service.db.WithContext(ctx).DeleteRecipe(request.GetId(), expectedVersion(request.Version))
+	blob.DeleteImages(service.blobs, images)
+	if err != nil {
+		return nil, writeError(err)
+	}
+	service.cache.WithContext(ctx).ClearRecipes()
+	return &recipepb.DeleteRecipeResponse{}, nil
+}
+
+// expectedVersion returns the version a write is conditioned on, or
+// persistence.AnyVersion when the client gave none.
+func expectedVersion(version *int64) int64 {
+	if version == nil {
+		return persistence.AnyVersion
+	}
+	return *version
+}
+
+// writeError maps an error from updating or deleting a recipe to a status.
+func writeError(err error) error {
+	switch err {
+	case db.ErrorVersionMismatch:
+		return status.Error(codes.FailedPrecondition, "Recipe has been modified")
+	case db.ErrorNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// send writes the recipes matching filter to stream as the database cursor
+// yields them, stopping early when the client goes away.
+func (service *RecipeService) send(stream grpc.ServerStream, filter persistence.RecipeFilter) error {
+	var sendErr error
+	err := service.db.WithContext(stream.Context()).EachMatchingRecipe(filter, func(recipe persistence.Recipe) error {
+		sendErr = stream.SendMsg(toRecipe(recipe))
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Package imaging validates uploaded pictures and renders the smaller
+// sizes served alongside them, using only the standard library.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+var ErrorUnsupportedType = errors.New("only JPEG and PNG images are supported")
+var ErrorTooLarge = errors.New("image dimensions are too large")
+
+// maxPixels bounds decoding so a small file cannot expand into a huge
+// bitmap in memory.
+const maxPixels = 40_000_000
+
+// Size is a rendition, bounded by MaxDimension on its longest side.
+type Size struct {
+	Name         string
+	MaxDimension int
+}
+
+var Medium = Size{Name: "medium", MaxDimension: 800}
+var Thumbnail = Size{Name: "thumbnail", MaxDimension: 200}
+
+// Rendition is an encoded picture ready to be stored.
+type Rendition struct {
+	Size        Size
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Extension returns the file extension for a supported content type.
+func Extension(contentType string) string {
+	if contentType == "image/png" {
+		return ".png"
+	}
+	return ".jpg"
+}
+
+// Decode sniffs the content type of data rather than trusting the client,
+// and decodes it if it is a supported image of acceptable dimensions.
+func Decode(data []byte) (image.Image, string, error) {
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, "", ErrorUnsupportedType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, "", ErrorTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	return img, contentType, nil
+}
+
+// Render scales img down to fit size and encodes it in the same format as
+// the original, so transparency in PNGs survives. Images already smaller
+// than size are encoded unscaled.
+func Render(img image.Image, contentType string, size Size) (Rendition, error) {
+	scaled := resize(img, size.MaxDimension)
+
+	var buffer bytes.Buffer
+	var err error
+	if contentType == "image/png" {
+		err = png.Encode(&buffer, scaled)
+	} else {
+		err = jpeg.Encode(&buffer, scaled, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return Rendition{}, err
+	}
+
+	bounds := scaled.Bounds()
+	return Rendition{
+		Size:        size,
+		Data:        buffer.Bytes(),
+		ContentType: contentType,
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+	}, nil
+}
+
+// resize shrinks img so its longest side is at most maxDimension. Every
+// output pixel averages the block of source pixels it covers, which avoids
+// the aliasing of nearest neighbour sampling when reducing a lot.
+func resize(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxDimension && height <= maxDimension {
+		return img
+	}
+
+	targetWidth, targetHeight := maxDimension, height*maxDimension/width
+	if height > width {
+		targetWidth, targetHeight = width*maxDimension/height, maxDimension
+	}
+	if targetWidth < 1 {
+		targetWidth = 1
+	}
+	if targetHeight < 1 {
+		targetHeight = 1
+	}
+
+	pixel := pixelReader(img)
+	target := image.NewNRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	for y := 0; y < targetHeight; y++ {
+		top, bottom := y*height/targetHeight, (y+1)*height/targetHeight
+		for x := 0; x < targetWidth; x++ {
+			left, right := x*width/targetWidth, (x+1)*width/targetWidth
+			target.SetNRGBA(x, y, average(pixel, bounds.Min, left, top, right, bottom))
+		}
+	}
+	return target
+}
+
+// pixelReader returns a function reading pixels of img as non-premultiplied
+// colour straight from the decoded image, so no full size copy is made.
+// The types the JPEG and PNG decoders usually return are read directly;
+// any other goes through its colour model.
+func pixelReader(img image.Image) func(x, y int) color.NRGBA {
+	switch img := img.(type) {
+	case *image.NRGBA:
+		return img.NRGBAAt
+	case *image.YCbCr:
+		return func(x, y int) color.NRGBA {
+			pixel := img.YCbCrAt(x, y)
+			r, g, b := color.YCbCrToRGB(pixel.Y, pixel.Cb, pixel.Cr)
+			return color.NRGBA{R: r, G: g, B: b, A: 0xff}
+		}
+	default:
+		return func(x, y int) color.NRGBA {
+			return color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+		}
+	}
+}
+
+func average(at func(x, y int) color.NRGBA, origin image.Point, left, top, right, bottom int) color.NRGBA {
+	var r, g, b, a, count uint64
+	for y := top; y < bottom; y++ {
+		for x := left; x < right; x++ {
+			pixel := at(origin.X+x, origin.Y+y)
+			// weight colour by alpha so transparent pixels do not darken edges
+			alpha := uint64(pixel.A)
+			r += uint64(pixel.R) * alpha
+			g += uint64(pixel.G) * alpha
+			b += uint64(pixel.B) * alpha
+			a += alpha
+			count++
+		}
+	}
+	if count == 0 || a == 0 {
+		return color.NRGBA{}
+	}
+	return color.NRGBA{
+		R: uint8(r / a),
+		G: uint8(g / a),
+		B: uint8(b / a),
+		A: uint8(a / count),
+	}
+}
@@ -0,0 +1,88 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// csvColumns are the recognised header names. List columns hold several
+// values separated by "|" or line breaks.
+var csvColumns = map[string]bool{
+	"name": true, "tags": true, "ingredients": true, "instructions": true, "servings": true,
+}
+
+// parseCSV reads a CSV file with a header row. Rows are numbered from the
+// first data row, so row 1 is the line after the header.
+func parseCSV(reader io.Reader) ([]Record, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if csvColumns[name] {
+			columns[name] = i
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("CSV header must include a name column")
+	}
+
+	var records []Record
+	for row := 1; ; row++ {
+		fields, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		record := Record{Row: row}
+		// a malformed row is reported and skipped, but an error reading the
+		// input would be returned by every further Read
+		var parseError *csv.ParseError
+		if errors.As(err, &parseError) {
+			record.Errors = []string{err.Error()}
+			records = append(records, record)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		value := func(column string) string {
+			if index, ok := columns[column]; ok && index < len(fields) {
+				return strings.TrimSpace(fields[index])
+			}
+			return ""
+		}
+		record.Recipe.Name = value("name")
+		record.Recipe.Tags = splitList(value("tags"))
+		for _, line := range splitList(value("ingredients")) {
+			record.Recipe.Ingredients = append(record.Recipe.Ingredients, persistence.ParseIngredient(line))
+		}
+		record.Recipe.Instructions = splitList(value("instructions"))
+		if servings := value("servings"); servings != "" {
+			if record.Recipe.Servings, err = strconv.Atoi(servings); err != nil {
+				record.Errors = append(record.Errors, "servings must be a whole number")
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == '|' || r == '\n' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,249 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/schemaorg"
+)
+
+var ErrorNoRecipeFound = errors.New("no recipe could be found in the page")
+
+// Where a draft was read from, from most to least reliable.
+const (
+	SourceJSONLD    = "json-ld"
+	SourceMicrodata = "microdata"
+	SourceHeuristic = "heuristic"
+)
+
+// Draft is a recipe extracted from a web page, returned for review before
+// it is saved. Warnings list what could not be read or still needs filling
+// in.
+type Draft struct {
+	Recipe   persistence.Recipe `json:"recipe"`
+	Source   string             `json:"source"`
+	Warnings []string           `json:"warnings"`
+}
+
+// ParseHTML extracts a recipe from a saved web page. Structured data is
+// preferred, JSON-LD first and then microdata; failing both, ingredient and
+// instruction lists are guessed from headings and class names. When a page
+// holds several recipes only the first is returned.
+func ParseHTML(reader io.Reader) (Draft, error) {
+	document, err := html.Parse(reader)
+	if err != nil {
+		return Draft{}, err
+	}
+
+	source := SourceJSONLD
+	nodes := jsonLDRecipes(document)
+	if len(nodes) == 0 {
+		source = SourceMicrodata
+		nodes = microdataRecipes(document)
+	}
+
+	var draft Draft
+	if len(nodes) > 0 {
+		var problems []string
+		draft.Recipe, problems = schemaorg.ToRecipe(nodes[0])
+		draft.Source = source
+		draft.Warnings = append(draft.Warnings, problems...)
+		if len(nodes) > 1 {
+			draft.Warnings = append(draft.Warnings, "the page holds several recipes; only the first was read")
+		}
+	} else {
+		var ok bool
+		if draft.Recipe, ok = guessRecipe(document); !ok {
+			return Draft{}, ErrorNoRecipeFound
+		}
+		draft.Source = SourceHeuristic
+	}
+
+	draft.Warnings = append(draft.Warnings, validate(draft.Recipe)...)
+	if draft.Warnings == nil {
+		draft.Warnings = []string{}
+	}
+	return draft, nil
+}
+
+// jsonLDRecipes reads the Recipe nodes of every JSON-LD script in the page.
+// Scripts that are not valid JSON are skipped, as pages often carry broken
+// or templated ones next to the real data.
+func jsonLDRecipes(document *html.Node) []map[string]interface{} {
+	var recipes []map[string]interface{}
+	for _, script := range findAll(document, func(node *html.Node) bool {
+		return node.DataAtom == atom.Script && strings.EqualFold(attribute(node, "type"), "application/ld+json")
+	}) {
+		if script.FirstChild == nil {
+			continue
+		}
+		nodes, err := schemaorg.FindRecipes([]byte(script.FirstChild.Data))
+		if err == nil {
+			recipes = append(recipes, nodes...)
+		}
+	}
+	return recipes
+}
+
+var (
+	ingredientsPattern  = regexp.MustCompile(`(?i)ingredient`)
+	instructionsPattern = regexp.MustCompile(`(?i)instruction|direction|method|preparation|steps`)
+)
+
+// guessRecipe looks for the ingredient and instruction lists of a page
+// without structured data. It reports false if it finds neither.
+func guessRecipe(document *html.Node) (persistence.Recipe, bool) {
+	var recipe persistence.Recipe
+	for _, line := range guessList(document, ingredientsPattern) {
+		recipe.Ingredients = append(recipe.Ingredients, persistence.ParseIngredient(line))
+	}
+	recipe.Instructions = guessList(document, instructionsPattern)
+	if len(recipe.Ingredients) == 0 && len(recipe.Instructions) == 0 {
+		return recipe, false
+	}
+
+	if title := findFirst(document, func(node *html.Node) bool {
+		return node.Type == html.ElementNode && node.Data == "meta" && attribute(node, "property") == "og:title"
+	}); title != nil {
+		recipe.Name = attribute(title, "content")
+	}
+	for _, tag := range []atom.Atom{atom.H1, atom.Title} {
+		if recipe.Name != "" {
+			break
+		}
+		if node := findFirst(document, func(node *html.Node) bool { return node.DataAtom == tag }); node != nil {
+			recipe.Name = collapse(textContent(node))
+		}
+	}
+	return recipe, true
+}
+
+// guessList finds the items of a list about a topic. A list, or a
+// container of one, whose class or id matches the pattern wins; otherwise
+// the first list after a heading that matches is used. Containers without
+// list items contribute their paragraphs instead.
+func guessList(document *html.Node, pattern *regexp.Regexp) []string {
+	container := findFirst(document, func(node *html.Node) bool {
+		return node.Type == html.ElementNode && node.DataAtom != atom.Body &&
+			(pattern.MatchString(attribute(node, "class")) || pattern.MatchString(attribute(node, "id"))) &&
+			len(listLines(node)) > 0
+	})
+	if container != nil {
+		return listLines(container)
+	}
+
+	heading := findFirst(document, func(node *html.Node) bool {
+		switch node.DataAtom {
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Strong, atom.B:
+			return pattern.MatchString(textContent(node))
+		}
+		return false
+	})
+	for node := next(heading); node != nil; node = next(node) {
+		if node.DataAtom == atom.Ul || node.DataAtom == atom.Ol {
+			return listLines(node)
+		}
+	}
+	return nil
+}
+
+// listLines returns the text of the list items inside node, or of its
+// paragraphs when it has no list items.
+func listLines(node *html.Node) []string {
+	var lines []string
+	for _, tag := range []atom.Atom{atom.Li, atom.P} {
+		for _, element := range findAll(node, func(child *html.Node) bool { return child != node && child.DataAtom == tag }) {
+			if line := collapse(textContent(element)); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 {
+			break
+		}
+	}
+	return lines
+}
+
+// next walks the document in order: first child, then next sibling, then
+// the next sibling of the nearest ancestor that has one.
+func next(node *html.Node) *html.Node {
+	if node == nil {
+		return nil
+	}
+	if node.FirstChild != nil {
+		return node.FirstChild
+	}
+	for ; node != nil; node = node.Parent {
+		if node.NextSibling != nil {
+			return node.NextSibling
+		}
+	}
+	return nil
+}
+
+// findFirst returns the first node under root, in document order, that
+// matches.
+func findFirst(root *html.Node, match func(*html.Node) bool) *html.Node {
+	if match(root) {
+		return root
+	}
+	for child := root.FirstChild; child != nil; child = child.NextSibling {
+		if found := findFirst(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func findAll(root *html.Node, match func(*html.Node) bool) []*html.Node {
+	var found []*html.Node
+	if match(root) {
+		found = append(found, root)
+	}
+	for child := root.FirstChild; child != nil; child = child.NextSibling {
+		found = append(found, findAll(child, match)...)
+	}
+	return found
+}
+
+// blockElements start a new line in textContent.
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Li: true, atom.Br: true, atom.Tr: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+}
+
+// textContent returns the visible text of node with a line break between
+// block elements, so multi-step text can still be split into steps.
+func textContent(node *html.Node) string {
+	var builder strings.Builder
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		switch {
+		case node.Type == html.TextNode:
+			builder.WriteString(node.Data)
+		case node.DataAtom == atom.Script || node.DataAtom == atom.Style:
+			return
+		}
+		if blockElements[node.DataAtom] {
+			builder.WriteString("\n")
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if blockElements[node.DataAtom] {
+			builder.WriteString("\n")
+		}
+	}
+	walk(node)
+	return strings.TrimSpace(builder.String())
+}
+
+func collapse(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+func TestParseHTMLPrefersJSONLD(t *testing.T) {
+	page := `<html><head>
+		<script type="application/ld+json">{{ templated }}</script>
+		<script type="application/ld+json">[
+			{"@type": "Recipe", "name": "Flatbread", "recipeIngredient": ["2 cups flour", "1 cup water"],
+			 "recipeInstructions": "Mix and fry.", "recipeYield": "4"},
+			{"@type": "Recipe", "name": "Hummus"}
+		]</script>
+	</head><body>
+		<div itemscope itemtype="https://schema.org/Recipe"><h1 itemprop="name">Ignored</h1></div>
+	</body></html>`
+	draft, err := ParseHTML(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.Source != SourceJSONLD || draft.Recipe.Name != "Flatbread" || draft.Recipe.Servings != 4 {
+		t.Errorf("draft = %+v", draft)
+	}
+	want := []string{"the page holds several recipes; only the first was read"}
+	if !reflect.DeepEqual(draft.Warnings, want) {
+		t.Errorf("warnings = %q, want %q", draft.Warnings, want)
+	}
+}
+
+func TestParseHTMLMicrodata(t *testing.T) {
+	page := `<html><body>
+		<article itemscope itemtype="http://schema.org/Recipe">
+			<h1 itemprop="name">Lemon Curd</h1>
+			<meta itemprop="recipeYield" content="2 jars">
+			<ul>
+				<li itemprop="recipeIngredient">3 lemons</li>
+				<li itemprop="recipeIngredient">100 g butter</li>
+			</ul>
+			<div itemprop="recipeInstructions">Whisk everything over a low heat.</div>
+			<div itemprop="author" itemscope itemtype="http://schema.org/Person"><span itemprop="name">Ann</span></div>
+		</article>
+	</body></html>`
+	draft, err := ParseHTML(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipe := draft.Recipe
+	if draft.Source != SourceMicrodata || recipe.Name != "Lemon Curd" || recipe.Servings != 2 ||
+		!reflect.DeepEqual(recipe.Instructions, []string{"Whisk everything over a low heat."}) {
+		t.Errorf("draft = %+v", draft)
+	}
+	want := []persistence.Ingredient{{Quantity: 3, Item: "lemons"}, {Quantity: 100, Unit: "g", Item: "butter"}}
+	if !reflect.DeepEqual(recipe.Ingredients, want) {
+		t.Errorf("ingredients = %+v, want %+v", recipe.Ingredients, want)
+	}
+}
+
+func TestParseHTMLGuessesWithoutStructuredData(t *testing.T) {
+	page := `<html><head>
+		<title>Blog | Best Brownies</title>
+		<meta property="og:title" content="Best Brownies">
+	</head><body>
+		<h1>My trip to the bakery</h1>
+		<ul class="recipe-ingredients-list"><li>200 g dark chocolate</li><li>3 eggs</li></ul>
+		<h3>Method</h3>
+		<p>Some chatter first.</p>
+		<ol><li>Melt the chocolate.</li><li>Beat in the eggs and bake.</li></ol>
+	</body></html>`
+	draft, err := ParseHTML(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipe := draft.Recipe
+	if draft.Source != SourceHeuristic || recipe.Name != "Best Brownies" || len(recipe.Ingredients) != 2 ||
+		!reflect.DeepEqual(recipe.Instructions, []string{"Melt the chocolate.", "Beat in the eggs and bake."}) {
+		t.Errorf("draft = %+v", draft)
+	}
+	if !reflect.DeepEqual(draft.Warnings, []string{}) {
+		t.Errorf("warnings = %q, want none", draft.Warnings)
+	}
+}
+
+// A draft is returned even when incomplete, with warnings saying what to
+// fill in before saving it.
+func TestParseHTMLWarnsAboutMissingParts(t *testing.T) {
+	page := `<html><body><h2>Ingredients</h2><ul><li>1 cup rice</li></ul></body></html>`
+	draft, err := ParseHTML(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"name is required", "at least one instruction is required"}
+	if !reflect.DeepEqual(draft.Warnings, want) {
+		t.Errorf("warnings = %q, want %q", draft.Warnings, want)
+	}
+}
+
+func TestParseHTMLWithoutRecipe(t *testing.T) {
+	page := `<html><body><h1>About us</h1><p>We like food.</p></body></html>`
+	if _, err := ParseHTML(strings.NewReader(page)); err != ErrorNoRecipeFound {
+		t.Errorf("error = %v, want %v", err, ErrorNoRecipeFound)
+	}
+}
@@ -0,0 +1,146 @@
+// Package importer reads recipes in bulk from JSON, CSV and schema.org
+// JSON-LD documents, validates them and stores the valid ones in batches.
+package importer
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+type Format string
+
+const (
+	JSON   Format = "json"
+	CSV    Format = "csv"
+	JSONLD Format = "jsonld"
+)
+
+var ErrorUnknownFormat = errors.New("import format must be one of json, csv or jsonld")
+
+const DefaultBatchSize = 100
+
+// Record is one parsed row or document, numbered from 1 in input order.
+type Record struct {
+	Row    int
+	Recipe persistence.Recipe
+	Errors []string
+}
+
+// RowError reports why a record was rejected.
+type RowError struct {
+	Row    int      `json:"row"`
+	Name   string   `json:"name,omitempty"`
+	Errors []string `json:"errors"`
+}
+
+type Report struct {
+	Total    int        `json:"total"`
+	Valid    int        `json:"valid"`
+	Imported int        `json:"imported"`
+	DryRun   bool       `json:"dryRun"`
+	Errors   []RowError `json:"errors"`
+}
+
+// FormatFromContentType guesses the format of an upload from its MIME type.
+func FormatFromContentType(contentType string) (Format, bool) {
+	contentType = strings.TrimSpace(strings.Split(contentType, ";")[0])
+	switch contentType {
+	case "application/json":
+		return JSON, true
+	case "text/csv", "application/csv":
+		return CSV, true
+	case "application/ld+json":
+		return JSONLD, true
+	}
+	return "", false
+}
+
+// Parse reads every record from reader. A malformed document as a whole is
+// returned as an error; problems with single records are attached to them.
+func Parse(format Format, reader io.Reader) ([]Record, error) {
+	var records []Record
+	var err error
+	switch format {
+	case JSON:
+		records, err = parseJSON(reader)
+	case CSV:
+		records, err = parseCSV(reader)
+	case JSONLD:
+		records, err = parseJSONLD(reader)
+	default:
+		return nil, ErrorUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range records {
+		records[i].Errors = append(records[i].Errors, validate(records[i].Recipe)...)
+	}
+	return records, nil
+}
+
+func validate(recipe persistence.Recipe) []string {
+	var problems []string
+	if strings.TrimSpace(recipe.Name) == "" {
+		problems = append(problems, "name is required")
+	}
+	if len(recipe.Ingredients) == 0 {
+		problems = append(problems, "at least one ingredient is required")
+	}
+	for i, ingredient := range recipe.Ingredients {
+		if strings.TrimSpace(ingredient.Item) == "" {
+			problems = append(problems, "ingredient "+strconv.Itoa(i+1)+" has no item")
+		}
+	}
+	if len(recipe.Instructions) == 0 {
+		problems = append(problems, "at least one instruction is required")
+	}
+	if recipe.Servings < 0 {
+		problems = append(problems, "servings cannot be negative")
+	}
+	return problems
+}
+
+// Import stores the valid records through db in batches of batchSize and
+// reports on every record. With dryRun nothing is stored.
+func Import(db persistence.DatabaseHandler, records []Record, batchSize int, dryRun bool) (Report, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	report := Report{Total: len(records), DryRun: dryRun, Errors: []RowError{}}
+
+	var batch []*persistence.Recipe
+	flush := func() error {
+		if len(batch) == 0 || dryRun {
+			batch = batch[:0]
+			return nil
+		}
+		if err := db.AddRecipes(batch); err != nil {
+			return err
+		}
+		report.Imported += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for i := range records {
+		record := &records[i]
+		if len(record.Errors) > 0 {
+			report.Errors = append(report.Errors, RowError{Row: record.Row, Name: record.Recipe.Name, Errors: record.Errors})
+			continue
+		}
+		report.Valid++
+		batch = append(batch, &record.Recipe)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+	return report, flush()
+}
@@ -0,0 +1,199 @@
+package importer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+func TestFormatFromContentType(t *testing.T) {
+	tests := map[string]Format{
+		"application/json":               JSON,
+		"text/csv; charset=utf-8":        CSV,
+		"application/ld+json; profile=x": JSONLD,
+	}
+	for contentType, want := range tests {
+		if got, ok := FormatFromContentType(contentType); !ok || got != want {
+			t.Errorf("FormatFromContentType(%q) = %q, %v, want %q", contentType, got, ok, want)
+		}
+	}
+	if _, ok := FormatFromContentType("text/plain"); ok {
+		t.Error("text/plain was accepted as an import format")
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	input := `[
+		{"id": "kept out", "name": "Toast", "ingredients": ["2 slices bread"], "instructions": ["Toast the bread."], "servings": 1},
+		{"name": "", "ingredients": [], "instructions": []},
+		{"name": 42}
+	]`
+	records, err := Parse(JSON, strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Parse returned %d records, want 3", len(records))
+	}
+
+	toast := records[0]
+	if toast.Row != 1 || len(toast.Errors) != 0 || toast.Recipe.ID != nil || toast.Recipe.Name != "Toast" {
+		t.Errorf("record 1 = %+v", toast)
+	}
+	if got, want := toast.Recipe.Ingredients[0], (persistence.Ingredient{Quantity: 2, Unit: "slice", Item: "bread"}); got != want {
+		t.Errorf("record 1 ingredient = %+v, want %+v", got, want)
+	}
+	want := []string{"name is required", "at least one ingredient is required", "at least one instruction is required"}
+	if !reflect.DeepEqual(records[1].Errors, want) {
+		t.Errorf("record 2 errors = %q, want %q", records[1].Errors, want)
+	}
+	if len(records[2].Errors) == 0 {
+		t.Error("record 3 decoded although its name is not a string")
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	input := "Name,Tags,Ingredients,Instructions,Servings,Notes\n" +
+		"Pancakes,breakfast|sweet,\"1 cup flour|1 egg\",Mix.|Fry.,4,ignored\n" +
+		"Soup,,2 cups stock,Simmer.,many,\n"
+	records, err := Parse(CSV, strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Parse returned %d records, want 2", len(records))
+	}
+
+	pancakes := records[0].Recipe
+	if len(records[0].Errors) != 0 || pancakes.Servings != 4 ||
+		!reflect.DeepEqual(pancakes.Tags, []string{"breakfast", "sweet"}) ||
+		!reflect.DeepEqual(pancakes.Instructions, []string{"Mix.", "Fry."}) ||
+		len(pancakes.Ingredients) != 2 || pancakes.Ingredients[1].Item != "egg" {
+		t.Errorf("record 1 = %+v", records[0])
+	}
+	if records[1].Row != 2 || !reflect.DeepEqual(records[1].Errors, []string{"servings must be a whole number"}) {
+		t.Errorf("record 2 = %+v", records[1])
+	}
+}
+
+func TestParseCSVReportsMalformedRows(t *testing.T) {
+	input := "name,servings\nToast,1\n\"Jam\" on \"bread\",2\nTea,1\n"
+	records, err := Parse(CSV, strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || len(records[1].Errors) == 0 || !strings.HasPrefix(records[1].Errors[0], "parse error") ||
+		records[2].Recipe.Name != "Tea" {
+		t.Errorf("Parse = %+v, want the malformed row 2 reported and row 3 read", records)
+	}
+}
+
+// failingReader returns its data and then the same error on every read.
+type failingReader struct {
+	data string
+	err  error
+}
+
+func (reader *failingReader) Read(p []byte) (int, error) {
+	if reader.data == "" {
+		return 0, reader.err
+	}
+	n := copy(p, reader.data)
+	reader.data = reader.data[n:]
+	return n, nil
+}
+
+func TestParseCSVReturnsReadErrors(t *testing.T) {
+	readErr := errors.New("connection reset")
+	reader := &failingReader{data: "name\nToast\n", err: readErr}
+	if _, err := Parse(CSV, reader); !errors.Is(err, readErr) {
+		t.Errorf("Parse = %v, want %v", err, readErr)
+	}
+}
+
+func TestParseCSVRequiresName(t *testing.T) {
+	if _, err := Parse(CSV, strings.NewReader("title,ingredients\nToast,bread\n")); err == nil {
+		t.Error("a CSV without a name column was accepted")
+	}
+}
+
+func TestParseJSONLD(t *testing.T) {
+	input := `{"@context": "https://schema.org", "@graph": [
+		{"@type": "WebPage", "name": "Not a recipe"},
+		{"@type": "Recipe", "name": "Lemonade", "recipeIngredient": ["4 lemons", "1 cup sugar"],
+		 "recipeInstructions": [{"@type": "HowToStep", "text": "Squeeze the lemons."}], "recipeYield": "6 servings",
+		 "keywords": "drinks, Summer"}
+	]}`
+	records, err := Parse(JSONLD, strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Parse returned %d records, want 1", len(records))
+	}
+	lemonade := records[0].Recipe
+	if len(records[0].Errors) != 0 || lemonade.Name != "Lemonade" || lemonade.Servings != 6 ||
+		!reflect.DeepEqual(lemonade.Tags, []string{"drinks", "summer"}) ||
+		!reflect.DeepEqual(lemonade.Instructions, []string{"Squeeze the lemons."}) {
+		t.Errorf("record = %+v", records[0])
+	}
+}
+
+func TestParseUnknownFormat(t *testing.T) {
+	if _, err := Parse("xml", strings.NewReader("")); err != ErrorUnknownFormat {
+		t.Errorf("error = %v, want %v", err, ErrorUnknownFormat)
+	}
+}
+
+// batchRecorder is a database that only records the batches it is given.
+type batchRecorder struct {
+	persistence.DatabaseHandler
+	batches [][]string
+}
+
+func (db *batchRecorder) AddRecipes(recipes []*persistence.Recipe) error {
+	var names []string
+	for _, recipe := range recipes {
+		names = append(names, recipe.Name)
+	}
+	db.batches = append(db.batches, names)
+	return nil
+}
+
+func importRecords() []Record {
+	return []Record{
+		{Row: 1, Recipe: persistence.Recipe{Name: "a"}},
+		{Row: 2, Recipe: persistence.Recipe{Name: "b"}, Errors: []string{"name is required"}},
+		{Row: 3, Recipe: persistence.Recipe{Name: "c"}},
+		{Row: 4, Recipe: persistence.Recipe{Name: "d"}},
+	}
+}
+
+func TestImport(t *testing.T) {
+	db := &batchRecorder{}
+	report, err := Import(db, importRecords(), 2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(db.batches, [][]string{{"a", "c"}, {"d"}}) {
+		t.Errorf("batches = %q, want [[a c] [d]]", db.batches)
+	}
+	want := Report{Total: 4, Valid: 3, Imported: 3, Errors: []RowError{{Row: 2, Name: "b", Errors: []string{"name is required"}}}}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	db := &batchRecorder{}
+	report, err := Import(db, importRecords(), 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(db.batches) != 0 || report.Imported != 0 || report.Valid != 3 || !report.DryRun {
+		t.Errorf("dry run stored %q and reported %+v", db.batches, report)
+	}
+}
@@ -0,0 +1,30 @@
+package importer
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// parseJSON reads an array of recipes in the API's own JSON shape. Each
+// element is decoded on its own so one bad record does not fail the rest.
+func parseJSON(reader io.Reader) ([]Record, error) {
+	var elements []json.RawMessage
+	if err := json.NewDecoder(reader).Decode(&elements); err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, len(elements))
+	for i, element := range elements {
+		records[i].Row = i + 1
+		var recipe persistence.Recipe
+		if err := json.Unmarshal(element, &recipe); err != nil {
+			records[i].Errors = []string{err.Error()}
+			continue
+		}
+		recipe.ID = nil
+		records[i].Recipe = recipe
+	}
+	return records, nil
+}
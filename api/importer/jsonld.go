@@ -0,0 +1,27 @@
+package importer
+
+import (
+	"io"
+
+	"github.com/tolopsy/foodpro/api/schemaorg"
+)
+
+// parseJSONLD reads one or more schema.org Recipe nodes from a JSON-LD
+// document, numbering them in document order.
+func parseJSONLD(reader io.Reader) ([]Record, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := schemaorg.FindRecipes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, len(nodes))
+	for i, node := range nodes {
+		recipe, problems := schemaorg.ToRecipe(node)
+		records[i] = Record{Row: i + 1, Recipe: recipe, Errors: problems}
+	}
+	return records, nil
+}
@@ -0,0 +1,108 @@
+package importer
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// microdataRecipes returns every schema.org Recipe item marked up with
+// HTML microdata, converted into the node shape schemaorg.FindRecipes
+// produces for JSON-LD so both can be read by schemaorg.ToRecipe.
+func microdataRecipes(document *html.Node) []map[string]interface{} {
+	var recipes []map[string]interface{}
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && hasAttribute(node, "itemscope") {
+			for _, itemType := range strings.Fields(attribute(node, "itemtype")) {
+				if strings.HasSuffix(itemType, "/Recipe") {
+					recipes = append(recipes, microdataItem(node))
+					return
+				}
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(document)
+	return recipes
+}
+
+// microdataItem collects the properties of an itemscope element. Nested
+// items become nested nodes and do not leak their properties outwards.
+// Properties given more than once become lists.
+func microdataItem(item *html.Node) map[string]interface{} {
+	node := map[string]interface{}{}
+	if itemType := attribute(item, "itemtype"); itemType != "" {
+		node["@type"] = itemType
+	}
+
+	var walk func(element *html.Node)
+	walk = func(element *html.Node) {
+		for child := element.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if names := strings.Fields(attribute(child, "itemprop")); len(names) > 0 {
+				value := microdataValue(child)
+				for _, name := range names {
+					switch existing := node[name].(type) {
+					case nil:
+						node[name] = value
+					case []interface{}:
+						node[name] = append(existing, value)
+					default:
+						node[name] = []interface{}{existing, value}
+					}
+				}
+			}
+			if !hasAttribute(child, "itemscope") {
+				walk(child)
+			}
+		}
+	}
+	walk(item)
+	return node
+}
+
+// microdataValue reads a property's value from where the microdata
+// specification puts it for each kind of element.
+func microdataValue(element *html.Node) interface{} {
+	if hasAttribute(element, "itemscope") {
+		return microdataItem(element)
+	}
+	switch element.Data {
+	case "meta":
+		return attribute(element, "content")
+	case "a", "link", "area":
+		return attribute(element, "href")
+	case "img", "audio", "video", "source", "embed", "iframe":
+		return attribute(element, "src")
+	case "time":
+		if value := attribute(element, "datetime"); value != "" {
+			return value
+		}
+	case "data", "meter":
+		return attribute(element, "value")
+	}
+	return textContent(element)
+}
+
+func hasAttribute(node *html.Node, name string) bool {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return true
+		}
+	}
+	return false
+}
+
+func attribute(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return strings.TrimSpace(attr.Val)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+// Package logging builds the server's structured logger and the middleware
+// that gives every request an ID and a logger carrying it.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/tolopsy/foodpro/api/config"
+)
+
+// New returns a logger writing to standard error at the configured level
+// and in the configured format.
+func New(settings config.Logging) *slog.Logger {
+	return NewWithWriter(os.Stderr, settings)
+}
+
+func NewWithWriter(writer io.Writer, settings config.Logging) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(settings.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	if settings.Format == "text" {
+		return slog.New(slog.NewTextHandler(writer, options))
+	}
+	return slog.New(slog.NewJSONHandler(writer, options))
+}
+
+type contextKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or fallback when there is
+// none.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return fallback
+}
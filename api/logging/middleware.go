@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tolopsy/foodpro/api/server/middleware/authentication/identity"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	requestIDKey    = "logging.requestID"
+	// longer IDs supplied by clients are replaced rather than logged
+	maxRequestIDLength = 128
+)
+
+// Middleware accepts the caller's X-Request-ID, or generates one, and echoes
+// it on the response. It stores a logger carrying the request ID, method and
+// route, and the trace and span IDs when the request is traced, on the
+// request context, and logs each request once it completes.
+func Middleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		requestID := ctx.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		ctx.Set(requestIDKey, requestID)
+		ctx.Header(RequestIDHeader, requestID)
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		requestLogger := logger.With(
+			slog.String("request_id", requestID),
+			slog.String("method", ctx.Request.Method),
+			slog.String("route", route),
+		)
+		// lets a log line be found from its trace and the other way round
+		if span := trace.SpanContextFromContext(ctx.Request.Context()); span.IsValid() {
+			requestLogger = requestLogger.With(
+				slog.String("trace_id", span.TraceID().String()),
+				slog.String("span_id", span.SpanID().String()),
+			)
+		}
+		ctx.Request = ctx.Request.WithContext(WithLogger(ctx.Request.Context(), requestLogger))
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		level := slog.LevelInfo
+		if status >= 500 {
+			level = slog.LevelError
+		} else if status >= 400 {
+			level = slog.LevelWarn
+		}
+		attributes := []slog.Attr{
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", ctx.ClientIP()),
+			slog.Int("size", ctx.Writer.Size()),
+		}
+		if username := identity.Username(ctx); username != "" {
+			attributes = append(attributes, slog.String("user", username))
+		}
+		if len(ctx.Errors) > 0 {
+			attributes = append(attributes, slog.String("errors", ctx.Errors.String()))
+		}
+		requestLogger.LogAttrs(ctx.Request.Context(), level, "Request handled", attributes...)
+	}
+}
+
+// Request returns the logger for the request being handled, including the
+// signed in user once authentication has run. Outside Middleware it falls
+// back to fallback.
+func Request(ctx *gin.Context, fallback *slog.Logger) *slog.Logger {
+	logger := FromContext(ctx.Request.Context(), fallback)
+	if username := identity.Username(ctx); username != "" {
+		logger = logger.With(slog.String("user", username))
+	}
+	return logger
+}
+
+// RequestID returns the ID assigned to the request by Middleware.
+func RequestID(ctx *gin.Context) string {
+	return ctx.GetString(requestIDKey)
+}
+
+// Recovery turns a panicking handler into a 500 response and logs the panic
+// with the request's logger.
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(ctx *gin.Context, recovered interface{}) {
+		Request(ctx, logger).Error("Recovered from panic",
+			slog.Any("panic", recovered),
+			slog.String("stack", string(debug.Stack())),
+		)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
+
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, char := range requestID {
+		if char <= ' ' || char > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	buffer := make([]byte, 16)
+	rand.Read(buffer)
+	return hex.EncodeToString(buffer)
+}
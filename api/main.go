@@ -1,90 +1,261 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
 
+	"github.com/tolopsy/foodpro/api/config"
+	graphql_api "github.com/tolopsy/foodpro/api/graphql"
+	grpc_api "github.com/tolopsy/foodpro/api/grpc"
+	"github.com/tolopsy/foodpro/api/logging"
+	"github.com/tolopsy/foodpro/api/metrics"
+	"github.com/tolopsy/foodpro/api/nutrition"
+	"github.com/tolopsy/foodpro/api/persistence"
 	"github.com/tolopsy/foodpro/api/provider"
 	"github.com/tolopsy/foodpro/api/server"
+	"github.com/tolopsy/foodpro/api/tracing"
 	auth "github.com/tolopsy/foodpro/api/server/middleware/authentication"
 	cors_middleware "github.com/tolopsy/foodpro/api/server/middleware/cors"
 	session_auth "github.com/tolopsy/foodpro/api/server/middleware/authentication/session"
 )
 
 var handler *server.Handler
+var shoppingHandler *server.ShoppingHandler
+var mealPlanHandler *server.MealPlanHandler
+var collectionHandler *server.CollectionHandler
+var reviewHandler *server.ReviewHandler
+var activityHandler *server.ActivityHandler
+var imageHandler *server.ImageHandler
+var healthHandler *server.HealthHandler
+var graphqlHandler *graphql_api.Handler
+var recipeService *grpc_api.RecipeService
 var authMiddleware auth.AuthMiddleware
-var corsRule cors.Config
+var corsRouter *cors_middleware.Router
+var settings config.Config
+var logger *slog.Logger
+var shutdownTracing func(context.Context) error
+var db persistence.DatabaseHandler
+var cache persistence.CacheHandler
 
 func init() {
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("Error while loading dev environment variables: " + err.Error())
+	var err error
+	settings, err = config.Load()
+	if err != nil {
+		log.Fatal("Error while loading configuration -> " + err.Error())
+	}
+	if err = settings.Validate(); err != nil {
+		log.Fatal(err.Error())
+	}
+	logger = logging.New(settings.Logging)
+	// anything still using the log package goes through the same handler
+	slog.SetDefault(logger)
+	logger.Info("Effective configuration", slog.String("config", settings.Redacted()))
+
+	shutdownTracing, err = tracing.Setup(context.Background(), settings.Tracing)
+	if err != nil {
+		fatal("Error while setting up tracing", err)
+	}
+
+	db, err = provider.NewDBHandler(settings.Database.Type, settings.Database.URI, settings.Database.Name, logger)
+	if err != nil {
+		fatal("Error while obtaining db handler", err)
+	}
+	db = tracing.InstrumentDatabase(metrics.InstrumentDatabase(db), settings.Database.Type)
+
+	cache, err = provider.NewCacheHandler(settings.Cache.Type, settings.Cache.Host, settings.Cache.Password, logger)
+	if err != nil {
+		fatal("Error while obtainiing cache server", err)
+	}
+	cache = tracing.InstrumentCache(cache, settings.Cache.Type)
+
+	foods, err := nutrition.DefaultTable()
+	if err != nil {
+		fatal("Error while loading food composition table", err)
+	}
+
+	blobs, err := provider.NewBlobStore(settings.Images.BlobType, settings.Images.BlobLocation, settings.Images.BaseURL)
+	if err != nil {
+		fatal("Error while obtaining blob store", err)
+	}
+
+	handler = server.NewHandler(db, cache, blobs, foods, logger)
+
+	shoppingLists, err := provider.NewShoppingListHandler(db)
+	if err != nil {
+		fatal("Error while obtaining shopping list handler", err)
 	}
+	shoppingHandler = server.NewShoppingHandler(db, shoppingLists)
 
-	dbType, dbURI, dbName := os.Getenv("DB_TYPE"), os.Getenv("DB_URI"), os.Getenv("DB_NAME")
-	cacheType, cacheHost, cachePassword := os.Getenv("CACHE_TYPE"), os.Getenv("CACHE_HOST"), os.Getenv("CACHE_PASSWORD")
+	mealPlans, err := provider.NewMealPlanHandler(db)
+	if err != nil {
+		fatal("Error while obtaining meal plan handler", err)
+	}
+	mealPlanHandler = server.NewMealPlanHandler(db, mealPlans)
 
-	SESS_STORE_ADDRESS := os.Getenv("SESS_STORE_ADDRESS")
-	SESS_STORE_PASSWORD := os.Getenv("SESS_STORE_PASSWORD")
-	SESS_STORE_KEY := os.Getenv("SESS_STORE_KEY")
+	collections, err := provider.NewCollectionHandler(db)
+	if err != nil {
+		fatal("Error while obtaining collection handler", err)
+	}
+	collectionHandler = server.NewCollectionHandler(db, collections)
 
-	db, err := provider.NewDBHandler(dbType, dbURI, dbName)
+	reviews, err := provider.NewReviewHandler(db)
 	if err != nil {
-		log.Fatal("Error while obtaining db handler -> " + err.Error())
+		fatal("Error while obtaining review handler", err)
 	}
+	reviewHandler = server.NewReviewHandler(db, cache, reviews)
 
-	cache, err := provider.NewCacheHandler(cacheType, cacheHost, cachePassword)
+	activity, err := provider.NewActivityHandler(db)
 	if err != nil {
-		log.Fatal("Error while obtainiing cache server -> " + err.Error())
+		fatal("Error while obtaining activity handler", err)
 	}
+	activityHandler = server.NewActivityHandler(db, cache, activity)
+
+	imageHandler = server.NewImageHandler(db, cache, blobs, settings.Images.MaxSize)
 
+	graphqlHandler, err = graphql_api.NewHandler(db, cache, blobs, logger)
 	if err != nil {
-		log.Fatal("Error while initializing session store -> " + err.Error())
+		fatal("Error while building GraphQL schema", err)
 	}
+	recipeService = grpc_api.NewRecipeService(db, cache, blobs, foods, logger)
 
-	handler = server.NewHandler(db, cache)
 	authMiddleware, err = session_auth.NewSessionAuth(
-		SESS_STORE_KEY,
-		SESS_STORE_ADDRESS,
-		SESS_STORE_PASSWORD,
+		settings.Session.Key,
+		settings.Session.Address,
+		settings.Session.Password,
 		db.VerifyUser,
 	)
 	if err != nil {
-		log.Fatal("Error while initializing authentication middleware -> " + err.Error())
+		fatal("Error while initializing authentication middleware", err)
 	}
 
-	// cors rule
-	corsRule = cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000"},
-		AllowMethods:     []string{"GET", "OPTIONS"},
-		AllowHeaders:     []string{"Origin"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
+	dependencies := map[string]server.Dependency{
+		settings.Database.Type: db,
+		settings.Cache.Type:    cache,
+	}
+	if sessions, ok := authMiddleware.(server.Dependency); ok {
+		dependencies["sessions"] = sessions
+	}
+	healthHandler = server.NewHealthHandler(dependencies)
+
+	corsRouter, err = cors_middleware.NewRouter(settings.CORS)
+	if err != nil {
+		fatal("Error while building CORS policies", err)
 	}
 }
 
 func main() {
-	engine := gin.Default()
-	engine.Use(cors_middleware.NewCorsMiddleware(corsRule))
-	auth.LoadSpecialFeatures(authMiddleware, engine)
-
-	engine.GET("/recipes", handler.FetchAllRecipes)
-	engine.GET("recipes/:id", handler.FetchOneRecipe)
-	engine.GET("/recipes/search", handler.SearchRecipesByTag)
-	engine.POST("/sign-in", authMiddleware.SignIn)
-	engine.GET("/sign-out", authMiddleware.SignOut)
-
-	authorized := engine.Group("/")
-	authorized.Use(authMiddleware.Authenticate())
-	authorized.POST("/recipes", handler.CreateNewRecipe)
-	authorized.PATCH("/recipes/:id", handler.UpdateRecipe)
-	authorized.DELETE("/recipes/:id", handler.DeleteRecipe)
-
-	engine.Run(":8080")
+	engine := server.NewEngine(server.Routes{
+		Recipes:       handler,
+		ShoppingLists: shoppingHandler,
+		MealPlans:     mealPlanHandler,
+		Collections:   collectionHandler,
+		Reviews:       reviewHandler,
+		Activity:      activityHandler,
+		Images:        imageHandler,
+		Health:        healthHandler,
+		GraphQL:       graphqlHandler,
+		Auth:          authMiddleware,
+		CORS:          corsRouter,
+		Logger:        logger,
+		Admins:        settings.Server.Admins,
+	})
+
+	httpServer := &http.Server{
+		Addr:     settings.Server.Address,
+		Handler:  engine,
+		ErrorLog: slog.NewLogLogger(logger.Handler(), slog.LevelError),
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fatal("Error while serving", err)
+		}
+	}()
+
+	var grpcServer *grpc.Server
+	if settings.Server.GRPCAddress != "" {
+		listener, err := net.Listen("tcp", settings.Server.GRPCAddress)
+		if err != nil {
+			fatal("Error while listening for gRPC", err)
+		}
+		grpcServer = grpc_api.NewServer(recipeService, authMiddleware, logger)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				fatal("Error while serving gRPC", err)
+			}
+		}()
+	}
+
+	<-ctx.Done()
+	stop()
+	logger.Info("Shutting down, press Ctrl+C again to force")
+	shutdown(httpServer, grpcServer, settings.Server.ShutdownTimeout)
+}
+
+// shutdown stops accepting HTTP requests and gRPC calls, waits for those in
+// flight and then closes the session store, cache and database, in that
+// order, as the handlers depend on them. Buffered spans are flushed last.
+// Whatever is still open when the timeout runs out is abandoned.
+func shutdown(httpServer *http.Server, grpcServer *grpc.Server, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	grpcDrained := make(chan struct{})
+	go func() {
+		defer close(grpcDrained)
+		if grpcServer != nil {
+			grpcServer.GracefulStop()
+		}
+	}()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		logger.Error("Error while draining requests", slog.String("error", err.Error()))
+	}
+	select {
+	case <-grpcDrained:
+	case <-ctx.Done():
+		// GracefulStop waits for streams however long they take, so cut
+		// off the ones still open
+		logger.Error("Error while draining gRPC calls", slog.String("error", ctx.Err().Error()))
+		if grpcServer != nil {
+			grpcServer.Stop()
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := authMiddleware.Close(); err != nil {
+			logger.Error("Error while closing session store", slog.String("error", err.Error()))
+		}
+		if err := cache.Close(); err != nil {
+			logger.Error("Error while closing cache", slog.String("error", err.Error()))
+		}
+		if err := db.Close(); err != nil {
+			logger.Error("Error while closing database", slog.String("error", err.Error()))
+		}
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("Error while flushing traces", slog.String("error", err.Error()))
+		}
+	}()
+	select {
+	case <-done:
+		logger.Info("Shutdown complete")
+	case <-ctx.Done():
+		logger.Warn("Shutdown timed out; exiting with connections still open")
+	}
 }
 
+// fatal logs an error that leaves the server unable to run, and exits.
+func fatal(message string, err error) {
+	logger.Error(message, slog.String("error", err.Error()))
+	os.Exit(1)
+}
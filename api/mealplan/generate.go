@@ -0,0 +1,77 @@
+// Package mealplan fills meal plans from a pool of candidate recipes and
+// exports them as iCalendar feeds.
+package mealplan
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+const DateLayout = "2006-01-02"
+
+var ErrorNoCandidates = errors.New("no recipes match the requested tags and constraints")
+
+// Constraints describe the plan to generate.
+type Constraints struct {
+	Start time.Time
+	Days  int
+	Slots []string
+	// recipes allowed in every slot, and per-slot overrides
+	Candidates     []persistence.Recipe
+	SlotCandidates map[string][]persistence.Recipe
+	Servings       int
+	// AllowRepeats lets a recipe appear more than once even while unused
+	// candidates remain
+	AllowRepeats bool
+	Seed         int64
+}
+
+// Generate assigns a recipe to every slot of every day. Recipes are drawn
+// at random without repetition until a slot's candidates are used up.
+func Generate(constraints Constraints) ([]persistence.MealPlanEntry, error) {
+	random := rand.New(rand.NewSource(constraints.Seed))
+	used := make(map[interface{}]bool)
+
+	var entries []persistence.MealPlanEntry
+	for day := 0; day < constraints.Days; day++ {
+		date := constraints.Start.AddDate(0, 0, day).Format(DateLayout)
+		for _, slot := range constraints.Slots {
+			candidates := constraints.Candidates
+			if override, ok := constraints.SlotCandidates[slot]; ok {
+				candidates = override
+			}
+			if len(candidates) == 0 {
+				return nil, ErrorNoCandidates
+			}
+
+			recipe := pick(random, candidates, used, constraints.AllowRepeats)
+			used[recipe.ID] = true
+			entries = append(entries, persistence.MealPlanEntry{
+				Date:       date,
+				Slot:       slot,
+				RecipeID:   recipe.IDString(),
+				RecipeName: recipe.Name,
+				Servings:   constraints.Servings,
+			})
+		}
+	}
+	return entries, nil
+}
+
+func pick(random *rand.Rand, candidates []persistence.Recipe, used map[interface{}]bool, allowRepeats bool) persistence.Recipe {
+	if !allowRepeats {
+		var fresh []persistence.Recipe
+		for _, recipe := range candidates {
+			if !used[recipe.ID] {
+				fresh = append(fresh, recipe)
+			}
+		}
+		if len(fresh) > 0 {
+			candidates = fresh
+		}
+	}
+	return candidates[random.Intn(len(candidates))]
+}
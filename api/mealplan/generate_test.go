@@ -0,0 +1,85 @@
+package mealplan
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+func recipes(names ...string) []persistence.Recipe {
+	result := make([]persistence.Recipe, len(names))
+	for i, name := range names {
+		result[i] = persistence.Recipe{ID: name, Name: name}
+	}
+	return result
+}
+
+func TestGenerate(t *testing.T) {
+	constraints := Constraints{
+		Start:          time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC),
+		Days:           3,
+		Slots:          []string{persistence.Breakfast, persistence.Dinner},
+		Candidates:     recipes("stew", "curry", "pie"),
+		SlotCandidates: map[string][]persistence.Recipe{persistence.Breakfast: recipes("oats", "eggs")},
+		Servings:       2,
+		Seed:           7,
+	}
+	entries, err := Generate(constraints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 6 {
+		t.Fatalf("Generate returned %d entries, want 6", len(entries))
+	}
+
+	dates := []string{"2024-03-30", "2024-03-30", "2024-03-31", "2024-03-31", "2024-04-01", "2024-04-01"}
+	dinners := make(map[string]bool)
+	for i, entry := range entries {
+		if entry.Date != dates[i] || entry.Servings != 2 || entry.RecipeID != entry.RecipeName {
+			t.Errorf("entry %d = %+v", i, entry)
+		}
+		switch entry.Slot {
+		case persistence.Breakfast:
+			if entry.RecipeID != "oats" && entry.RecipeID != "eggs" {
+				t.Errorf("breakfast %d is %s, not one of its own candidates", i, entry.RecipeID)
+			}
+		case persistence.Dinner:
+			dinners[entry.RecipeID] = true
+		}
+	}
+	// three dinners from three candidates cannot repeat
+	if len(dinners) != 3 {
+		t.Errorf("dinners repeat while unused candidates remained: %v", dinners)
+	}
+
+	again, _ := Generate(constraints)
+	if !reflect.DeepEqual(entries, again) {
+		t.Error("the same seed generated a different plan")
+	}
+}
+
+func TestGenerateRepeatsOnceCandidatesRunOut(t *testing.T) {
+	entries, err := Generate(Constraints{Days: 3, Slots: []string{persistence.Lunch}, Candidates: recipes("soup")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.RecipeID != "soup" {
+			t.Errorf("lunch is %s, want soup", entry.RecipeID)
+		}
+	}
+}
+
+func TestGenerateWithoutCandidates(t *testing.T) {
+	constraints := Constraints{
+		Days:           1,
+		Slots:          []string{persistence.Lunch, persistence.Snack},
+		Candidates:     recipes("soup"),
+		SlotCandidates: map[string][]persistence.Recipe{persistence.Snack: nil},
+	}
+	if _, err := Generate(constraints); err != ErrorNoCandidates {
+		t.Errorf("error = %v, want %v", err, ErrorNoCandidates)
+	}
+}
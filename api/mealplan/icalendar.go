@@ -0,0 +1,101 @@
+package mealplan
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// slotTimes are the local times meals are placed at in calendar feeds.
+var slotTimes = map[string]string{
+	persistence.Breakfast: "080000",
+	persistence.Lunch:     "123000",
+	persistence.Snack:     "160000",
+	persistence.Dinner:    "183000",
+}
+
+// ICalendar renders a plan as an RFC 5545 calendar with one hour long
+// events in floating local time, so meals stay at the same hour in any time
+// zone the feed is viewed from.
+func ICalendar(plan persistence.MealPlan, planID string, recipeURL func(string) string) string {
+	var builder strings.Builder
+	write := func(line string) {
+		builder.WriteString(fold(line))
+		builder.WriteString("\r\n")
+	}
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	write("BEGIN:VCALENDAR")
+	write("VERSION:2.0")
+	write("PRODID:-//foodpro//meal planner//EN")
+	write("CALSCALE:GREGORIAN")
+	write("X-WR-CALNAME:" + escape(plan.Name))
+	for _, entry := range plan.Entries {
+		date, err := time.Parse(DateLayout, entry.Date)
+		if err != nil {
+			continue
+		}
+		slotTime, ok := slotTimes[entry.Slot]
+		if !ok {
+			continue
+		}
+		start := date.Format("20060102") + "T" + slotTime
+		end := date.Format("20060102") + "T" + endTime(slotTime)
+
+		summary := entry.RecipeName
+		if summary == "" {
+			summary = entry.RecipeID
+		}
+		write("BEGIN:VEVENT")
+		write(fmt.Sprintf("UID:%s-%s-%s@foodpro", planID, entry.Date, entry.Slot))
+		write("DTSTAMP:" + stamp)
+		write("DTSTART:" + start)
+		write("DTEND:" + end)
+		write("SUMMARY:" + escape(strings.ToUpper(entry.Slot[:1])+entry.Slot[1:]+": "+summary))
+		if recipeURL != nil {
+			write("URL:" + recipeURL(entry.RecipeID))
+		}
+		if entry.Servings > 0 {
+			write(fmt.Sprintf("DESCRIPTION:Servings: %d", entry.Servings))
+		}
+		write("END:VEVENT")
+	}
+	write("END:VCALENDAR")
+	return builder.String()
+}
+
+// endTime adds an hour to an HHMMSS time.
+func endTime(start string) string {
+	parsed, err := time.Parse("150405", start)
+	if err != nil {
+		return start
+	}
+	return parsed.Add(time.Hour).Format("150405")
+}
+
+func escape(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return replacer.Replace(text)
+}
+
+// fold splits content lines longer than 75 octets as RFC 5545 requires,
+// without breaking multi-byte characters.
+func fold(line string) string {
+	if len(line) <= 75 {
+		return line
+	}
+	var builder strings.Builder
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > 75 {
+			builder.WriteString("\r\n ")
+			length = 1
+		}
+		builder.WriteRune(r)
+		length += size
+	}
+	return builder.String()
+}
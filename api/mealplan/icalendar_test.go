@@ -0,0 +1,54 @@
+package mealplan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+func TestICalendar(t *testing.T) {
+	plan := persistence.MealPlan{
+		Name: "Week one; spring, 2024",
+		Entries: []persistence.MealPlanEntry{
+			{Date: "2024-03-31", Slot: persistence.Dinner, RecipeID: "r1", RecipeName: "Roast chicken", Servings: 4},
+			{Date: "2024-04-01", Slot: persistence.Breakfast, RecipeID: "r2"},
+			{Date: "not a date", Slot: persistence.Lunch, RecipeID: "r3"},
+		},
+	}
+	feed := ICalendar(plan, "plan1", func(id string) string { return "https://example.com/recipes/" + id })
+
+	for _, line := range []string{
+		"BEGIN:VCALENDAR",
+		`X-WR-CALNAME:Week one\; spring\, 2024`,
+		"UID:plan1-2024-03-31-dinner@foodpro",
+		"DTSTART:20240331T183000",
+		"DTEND:20240331T193000",
+		"SUMMARY:Dinner: Roast chicken",
+		"URL:https://example.com/recipes/r1",
+		"DESCRIPTION:Servings: 4",
+		"DTSTART:20240401T080000",
+		"SUMMARY:Breakfast: r2",
+		"END:VCALENDAR",
+	} {
+		if !strings.Contains(feed, "\r\n"+line+"\r\n") && !strings.HasPrefix(feed, line+"\r\n") {
+			t.Errorf("feed is missing the line %q", line)
+		}
+	}
+	if strings.Count(feed, "BEGIN:VEVENT") != 2 {
+		t.Errorf("feed has %d events, want 2 since an undated entry is skipped", strings.Count(feed, "BEGIN:VEVENT"))
+	}
+}
+
+func TestFold(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+	folded := fold(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("folded line is %d octets long", len(part))
+		}
+	}
+	if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != line {
+		t.Errorf("unfolding gives %q, want %q", unfolded, line)
+	}
+}
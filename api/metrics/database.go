@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// DatabaseHandler times every call to the DatabaseHandler it wraps, so any
+// backend is instrumented the same way.
+type DatabaseHandler struct {
+	next persistence.DatabaseHandler
+}
+
+func InstrumentDatabase(next persistence.DatabaseHandler) *DatabaseHandler {
+	return &DatabaseHandler{next: next}
+}
+
+// Unwrap returns the wrapped handler, for code that needs the concrete
+// backend.
+func (db *DatabaseHandler) Unwrap() persistence.DatabaseHandler {
+	return db.next
+}
+
+// observe records a call that started at start. Use it deferred with the
+// method's named error result.
+func observe(method string, start time.Time, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	dbDuration.WithLabelValues(method, outcome).Observe(time.Since(start).Seconds())
+}
+
+func (db *DatabaseHandler) FetchAllRecipes() (recipes []persistence.Recipe, err error) {
+	defer func(start time.Time) { observe("FetchAllRecipes", start, err) }(time.Now())
+	return db.next.FetchAllRecipes()
+}
+
+func (db *DatabaseHandler) EachRecipe(fn func(persistence.Recipe) error) (err error) {
+	defer func(start time.Time) { observe("EachRecipe", start, err) }(time.Now())
+	return db.next.EachRecipe(fn)
+}
+
+func (db *DatabaseHandler) EachMatchingRecipe(filter persistence.RecipeFilter, fn func(persistence.Recipe) error) (err error) {
+	defer func(start time.Time) { observe("EachMatchingRecipe", start, err) }(time.Now())
+	return db.next.EachMatchingRecipe(filter, fn)
+}
+
+func (db *DatabaseHandler) GetRecipe(id string) (recipe persistence.Recipe, err error) {
+	defer func(start time.Time) { observe("GetRecipe", start, err) }(time.Now())
+	return db.next.GetRecipe(id)
+}
+
+func (db *DatabaseHandler) FetchRecipesByIDs(ids []string) (recipes []persistence.Recipe, err error) {
+	defer func(start time.Time) { observe("FetchRecipesByIDs", start, err) }(time.Now())
+	return db.next.FetchRecipesByIDs(ids)
+}
+
+func (db *DatabaseHandler) FindRecipesByTag(tag string) (recipes []persistence.Recipe, err error) {
+	defer func(start time.Time) { observe("FindRecipesByTag", start, err) }(time.Now())
+	return db.next.FindRecipesByTag(tag)
+}
+
+func (db *DatabaseHandler) SearchRecipes(filter persistence.RecipeFilter) (recipes []persistence.Recipe, err error) {
+	defer func(start time.Time) { observe("SearchRecipes", start, err) }(time.Now())
+	return db.next.SearchRecipes(filter)
+}
+
+func (db *DatabaseHandler) AddRecipe(recipe *persistence.Recipe) (err error) {
+	defer func(start time.Time) { observe("AddRecipe", start, err) }(time.Now())
+	return db.next.AddRecipe(recipe)
+}
+
+func (db *DatabaseHandler) AddRecipes(recipes []*persistence.Recipe) (err error) {
+	defer func(start time.Time) { observe("AddRecipes", start, err) }(time.Now())
+	return db.next.AddRecipes(recipes)
+}
+
+func (db *DatabaseHandler) UpdateRecipe(id string, recipe persistence.Recipe, version int64) (err error) {
+	defer func(start time.Time) { observe("UpdateRecipe", start, err) }(time.Now())
+	return db.next.UpdateRecipe(id, recipe, version)
+}
+
+func (db *DatabaseHandler) DeleteRecipe(id string, version int64) (images []persistence.RecipeImage, err error) {
+	defer func(start time.Time) { observe("DeleteRecipe", start, err) }(time.Now())
+	return db.next.DeleteRecipe(id, version)
+}
+
+func (db *DatabaseHandler) AddRecipeImage(id string, image persistence.RecipeImage) (err error) {
+	defer func(start time.Time) { observe("AddRecipeImage", start, err) }(time.Now())
+	return db.next.AddRecipeImage(id, image)
+}
+
+func (db *DatabaseHandler) RemoveRecipeImage(id, imageID string) (image persistence.RecipeImage, err error) {
+	defer func(start time.Time) { observe("RemoveRecipeImage", start, err) }(time.Now())
+	return db.next.RemoveRecipeImage(id, imageID)
+}
+
+func (db *DatabaseHandler) VerifyUser(user persistence.User) bool {
+	defer func(start time.Time) { observe("VerifyUser", start, nil) }(time.Now())
+	return db.next.VerifyUser(user)
+}
+
+func (db *DatabaseHandler) WithContext(ctx context.Context) persistence.DatabaseHandler {
+	return InstrumentDatabase(db.next.WithContext(ctx))
+}
+
+func (db *DatabaseHandler) Ping(ctx context.Context) (err error) {
+	defer func(start time.Time) { observe("Ping", start, err) }(time.Now())
+	return db.next.Ping(ctx)
+}
+
+func (db *DatabaseHandler) ServerVersion(ctx context.Context) (version string, err error) {
+	defer func(start time.Time) { observe("ServerVersion", start, err) }(time.Now())
+	return db.next.ServerVersion(ctx)
+}
+
+func (db *DatabaseHandler) Close() error {
+	return db.next.Close()
+}
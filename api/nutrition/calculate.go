@@ -0,0 +1,101 @@
+package nutrition
+
+import (
+	"math"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/units"
+)
+
+// Report is the nutrition breakdown of a recipe.
+type Report struct {
+	Servings   int       `json:"servings"`
+	Total      Nutrients `json:"total"`
+	PerServing Nutrients `json:"perServing"`
+	// ingredient lines that could not be matched to a food or weighed, and
+	// are therefore missing from the totals
+	Unmatched []string `json:"unmatched"`
+}
+
+// Calculate totals the nutrients of every ingredient of recipe. Recipes
+// without servings are reported as a single serving.
+func (table *Table) Calculate(recipe persistence.Recipe) Report {
+	report := Report{Servings: recipe.Servings, Unmatched: []string{}}
+	if report.Servings <= 0 {
+		report.Servings = 1
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		food, ok := table.Match(ingredient.Item)
+		if !ok {
+			report.Unmatched = append(report.Unmatched, ingredient.String())
+			continue
+		}
+		grams, ok := weigh(ingredient, food)
+		if !ok {
+			report.Unmatched = append(report.Unmatched, ingredient.String())
+			continue
+		}
+		report.Total = report.Total.add(food.Per100g.scale(grams / 100))
+	}
+
+	report.PerServing = report.Total.scale(1 / float64(report.Servings)).rounded()
+	report.Total = report.Total.rounded()
+	return report
+}
+
+// weigh converts an ingredient quantity into grams using the food's density
+// for volumes and its piece weight for counted items.
+func weigh(ingredient persistence.Ingredient, food Food) (float64, bool) {
+	quantity := ingredient.Quantity
+	if quantity == 0 {
+		// "salt to taste" and the like contribute nothing measurable
+		return 0, true
+	}
+
+	switch units.KindOf(ingredient.Unit) {
+	case units.Mass:
+		grams, err := units.Convert(quantity, ingredient.Unit, "g")
+		return grams, err == nil
+	case units.Volume:
+		if food.Density == 0 {
+			return 0, false
+		}
+		millilitres, err := units.Convert(quantity, ingredient.Unit, "ml")
+		return millilitres * food.Density, err == nil
+	default:
+		if food.PieceWeight == 0 {
+			return 0, false
+		}
+		return quantity * food.PieceWeight, true
+	}
+}
+
+func (n Nutrients) add(other Nutrients) Nutrients {
+	return Nutrients{
+		Calories: n.Calories + other.Calories, Protein: n.Protein + other.Protein,
+		Fat: n.Fat + other.Fat, Carbs: n.Carbs + other.Carbs, Fiber: n.Fiber + other.Fiber,
+		Sugar: n.Sugar + other.Sugar, Sodium: n.Sodium + other.Sodium,
+		Calcium: n.Calcium + other.Calcium, Iron: n.Iron + other.Iron,
+		Potassium: n.Potassium + other.Potassium, VitaminC: n.VitaminC + other.VitaminC,
+	}
+}
+
+func (n Nutrients) scale(factor float64) Nutrients {
+	return Nutrients{
+		Calories: n.Calories * factor, Protein: n.Protein * factor, Fat: n.Fat * factor,
+		Carbs: n.Carbs * factor, Fiber: n.Fiber * factor, Sugar: n.Sugar * factor,
+		Sodium: n.Sodium * factor, Calcium: n.Calcium * factor, Iron: n.Iron * factor,
+		Potassium: n.Potassium * factor, VitaminC: n.VitaminC * factor,
+	}
+}
+
+func (n Nutrients) rounded() Nutrients {
+	round := func(value float64) float64 { return math.Round(value*10) / 10 }
+	return Nutrients{
+		Calories: math.Round(n.Calories), Protein: round(n.Protein), Fat: round(n.Fat),
+		Carbs: round(n.Carbs), Fiber: round(n.Fiber), Sugar: round(n.Sugar),
+		Sodium: math.Round(n.Sodium), Calcium: math.Round(n.Calcium), Iron: round(n.Iron),
+		Potassium: math.Round(n.Potassium), VitaminC: round(n.VitaminC),
+	}
+}
@@ -0,0 +1,137 @@
+// Package nutrition estimates the nutritional content of recipes from a
+// food composition table with values per 100 grams.
+package nutrition
+
+import (
+	"embed"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+//go:embed data/foods.csv
+var bundled embed.FS
+
+var ErrorMalformedTable = errors.New("food composition table is malformed")
+
+// Nutrients holds the amounts the API reports. Energy is in kcal, macros in
+// grams and minerals and vitamins in milligrams.
+type Nutrients struct {
+	Calories  float64 `json:"calories"`
+	Protein   float64 `json:"protein"`
+	Fat       float64 `json:"fat"`
+	Carbs     float64 `json:"carbs"`
+	Fiber     float64 `json:"fiber"`
+	Sugar     float64 `json:"sugar"`
+	Sodium    float64 `json:"sodium"`
+	Calcium   float64 `json:"calcium"`
+	Iron      float64 `json:"iron"`
+	Potassium float64 `json:"potassium"`
+	VitaminC  float64 `json:"vitaminC"`
+}
+
+// Food is one row of the composition table.
+type Food struct {
+	Name string
+	// nutrients in 100 grams of the food
+	Per100g Nutrients
+	// grams per millilitre, zero if the food is not measured by volume
+	Density float64
+	// grams in one piece, zero if the food is not counted
+	PieceWeight float64
+}
+
+type Table struct {
+	foods []Food
+	// lookup from every name and alias to the index of its food
+	names map[string]int
+}
+
+var columns = []string{
+	"name", "aliases", "kcal", "protein_g", "fat_g", "carbs_g", "fiber_g", "sugar_g",
+	"sodium_mg", "calcium_mg", "iron_mg", "potassium_mg", "vitamin_c_mg",
+	"density_g_per_ml", "piece_g",
+}
+
+// DefaultTable loads the table bundled with the binary.
+func DefaultTable() (*Table, error) {
+	file, err := bundled.Open("data/foods.csv")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadTable(file)
+}
+
+// LoadTable reads a USDA-style CSV with the columns listed in columns.
+// Aliases are separated by "|".
+func LoadTable(reader io.Reader) (*Table, error) {
+	rows, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || len(rows[0]) != len(columns) {
+		return nil, ErrorMalformedTable
+	}
+
+	table := &Table{names: make(map[string]int)}
+	for _, row := range rows[1:] {
+		values := make([]float64, len(columns))
+		for i := 2; i < len(columns); i++ {
+			if row[i] == "" {
+				continue
+			}
+			if values[i], err = strconv.ParseFloat(row[i], 64); err != nil {
+				return nil, ErrorMalformedTable
+			}
+		}
+
+		food := Food{
+			Name: row[0],
+			Per100g: Nutrients{
+				Calories: values[2], Protein: values[3], Fat: values[4], Carbs: values[5],
+				Fiber: values[6], Sugar: values[7], Sodium: values[8], Calcium: values[9],
+				Iron: values[10], Potassium: values[11], VitaminC: values[12],
+			},
+			Density:     values[13],
+			PieceWeight: values[14],
+		}
+		table.foods = append(table.foods, food)
+
+		table.names[persistence.NormalizeItem(row[0])] = len(table.foods) - 1
+		for _, alias := range strings.Split(row[1], "|") {
+			if alias = persistence.NormalizeItem(alias); alias != "" {
+				table.names[alias] = len(table.foods) - 1
+			}
+		}
+	}
+	return table, nil
+}
+
+// Match finds the food an ingredient item refers to. An exact name or alias
+// wins; otherwise the longest name contained in the item as whole words is
+// used, so "ripe bananas" matches "banana". Names of equal length are
+// settled alphabetically, so the same item always matches the same food.
+func (table *Table) Match(item string) (Food, bool) {
+	item = persistence.NormalizeItem(item)
+	if index, ok := table.names[item]; ok {
+		return table.foods[index], true
+	}
+
+	best, bestName := -1, ""
+	padded := " " + item + " "
+	for name, index := range table.names {
+		better := len(name) > len(bestName) || len(name) == len(bestName) && name < bestName
+		if better && strings.Contains(padded, " "+name+" ") {
+			best, bestName = index, name
+		}
+	}
+	if best < 0 {
+		return Food{}, false
+	}
+	return table.foods[best], true
+}
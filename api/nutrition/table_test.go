@@ -0,0 +1,99 @@
+package nutrition
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+const testCSV = `name,aliases,kcal,protein_g,fat_g,carbs_g,fiber_g,sugar_g,sodium_mg,calcium_mg,iron_mg,potassium_mg,vitamin_c_mg,density_g_per_ml,piece_g
+all-purpose flour,flour|plain flour,364,10.3,1,76.3,2.7,0.3,2,15,4.6,107,0,0.53,
+egg,eggs,143,12.6,9.5,0.7,0,0.4,142,56,1.8,138,0,,50
+salt,table salt,0,0,0,0,0,0,38758,24,0.3,8,0,1.2,
+kiwi,,61,1.1,0.5,14.7,3,9,3,34,0.3,312,92.7,,70
+lime,,30,0.7,0.2,10.5,2.8,1.7,2,33,0.6,102,29.1,,67
+`
+
+func testTable(t *testing.T) *Table {
+	t.Helper()
+	table, err := LoadTable(strings.NewReader(testCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestLoadTableRejectsWrongColumns(t *testing.T) {
+	if _, err := LoadTable(strings.NewReader("name,kcal\nflour,364\n")); err != ErrorMalformedTable {
+		t.Errorf("error = %v, want %v", err, ErrorMalformedTable)
+	}
+}
+
+func TestDefaultTableLoads(t *testing.T) {
+	if _, err := DefaultTable(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	table := testTable(t)
+	tests := map[string]string{
+		"Flour":                 "all-purpose flour",
+		"plain flour":           "all-purpose flour",
+		"Eggs":                  "egg",
+		"large free range eggs": "egg",
+		"unbleached flour":      "all-purpose flour",
+	}
+	for item, want := range tests {
+		if food, ok := table.Match(item); !ok || food.Name != want {
+			t.Errorf("Match(%q) = %q, %v, want %q", item, food.Name, ok, want)
+		}
+	}
+	if food, ok := table.Match("saffron"); ok {
+		t.Errorf("Match(%q) = %q, want no match", "saffron", food.Name)
+	}
+}
+
+// Names of the same length must always settle the same way, whatever order
+// the lookup map is ranged in.
+func TestMatchBreaksTiesByName(t *testing.T) {
+	table := testTable(t)
+	for i := 0; i < 50; i++ {
+		if food, _ := table.Match("lime and kiwi tart"); food.Name != "kiwi" {
+			t.Fatalf("Match picked %q, want kiwi", food.Name)
+		}
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	recipe := persistence.Recipe{Servings: 2, Ingredients: []persistence.Ingredient{
+		{Quantity: 1, Unit: "cup", Item: "flour"},
+		{Quantity: 2, Item: "eggs"},
+		{Item: "salt"},
+		{Quantity: 1, Unit: "cup", Item: "eggs"},
+		{Quantity: 1, Unit: "sprig", Item: "rosemary"},
+	}}
+	report := testTable(t).Calculate(recipe)
+
+	// 1 cup of flour weighs 236.588 ml * 0.53 g/ml and two eggs 100 g
+	if report.Total.Calories != 599 || report.PerServing.Calories != 300 {
+		t.Errorf("calories = %v total, %v per serving, want 599 and 300", report.Total.Calories, report.PerServing.Calories)
+	}
+	want := []string{"1 cup eggs", "1 sprig rosemary"}
+	if len(report.Unmatched) != len(want) {
+		t.Fatalf("Unmatched = %q, want %q", report.Unmatched, want)
+	}
+	for i := range want {
+		if report.Unmatched[i] != want[i] {
+			t.Errorf("Unmatched[%d] = %q, want %q", i, report.Unmatched[i], want[i])
+		}
+	}
+}
+
+func TestCalculateWithoutServings(t *testing.T) {
+	report := testTable(t).Calculate(persistence.Recipe{Ingredients: []persistence.Ingredient{{Quantity: 1, Item: "egg"}}})
+	if report.Servings != 1 || report.PerServing != report.Total {
+		t.Errorf("report = %+v, want one serving equal to the total", report)
+	}
+}
@@ -0,0 +1,67 @@
+// Package openapi serves the API's OpenAPI 3 document and a Swagger UI page
+// for browsing it. The document is written by hand in openapi.json; the
+// server package's tests check that it covers every registered route.
+package openapi
+
+import (
+	_ "embed"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+//go:embed openapi.json
+var document []byte
+
+//go:embed index.html
+var index []byte
+
+// Document returns the OpenAPI document as JSON.
+func Document() []byte {
+	return document
+}
+
+// Handler serves the OpenAPI document.
+func Handler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "application/json; charset=utf-8", document)
+	}
+}
+
+// assets are the Swagger UI files index.html loads. Only these are served,
+// so the rest of the bundled distribution stays private.
+var assets = map[string]bool{
+	"swagger-ui.css":                  true,
+	"swagger-ui-bundle.js":            true,
+	"swagger-ui-standalone-preset.js": true,
+	"favicon-32x32.png":               true,
+	"favicon-16x16.png":               true,
+}
+
+// UI serves Swagger UI, pointed at the document, from a route ending in a
+// *file wildcard such as /docs/*file.
+func UI() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		name := strings.TrimPrefix(ctx.Param("file"), "/")
+		if name == "" || name == "index.html" {
+			ctx.Data(http.StatusOK, "text/html; charset=utf-8", index)
+			return
+		}
+		if !assets[name] {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "File does not exist"})
+			return
+		}
+
+		data, err := swaggerFiles.ReadFile(name)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Header("Cache-Control", "public, max-age=86400")
+		ctx.Data(http.StatusOK, mime.TypeByExtension(path.Ext(name)), data)
+	}
+}
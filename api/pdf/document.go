@@ -0,0 +1,146 @@
+// Package pdf renders printable recipes, collections and meal plans as PDF
+// documents. It writes PDF directly using the standard Helvetica fonts
+// every reader ships with, so no fonts need embedding and no external
+// tools are involved.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+var fontNames = map[Font]string{
+	Regular: "Helvetica",
+	Bold:    "Helvetica-Bold",
+}
+
+// Document collects the content of each page. Coordinates are in points
+// measured from the top left corner of the page, unlike PDF itself which
+// measures from the bottom left.
+type Document struct {
+	width, height float64
+	title         string
+	pages         []*bytes.Buffer
+	current       *bytes.Buffer
+}
+
+func NewDocument(paper Paper, title string) *Document {
+	return &Document{width: paper.Width, height: paper.Height, title: title}
+}
+
+// AddPage starts a new page and makes it the current one.
+func (document *Document) AddPage() {
+	document.current = new(bytes.Buffer)
+	document.pages = append(document.pages, document.current)
+}
+
+// PageCount returns the number of pages added so far.
+func (document *Document) PageCount() int {
+	return len(document.pages)
+}
+
+// SetPage makes an earlier page current again, counting from 1, so
+// content such as page numbers can be added once the total is known.
+func (document *Document) SetPage(number int) {
+	document.current = document.pages[number-1]
+}
+
+// Text draws a single line of text with its baseline at y.
+func (document *Document) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(document.current, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		font+1, number(size), number(x), number(document.height-y), escape(text))
+}
+
+// Gray sets the colour of text drawn after it, from 0 for black to 1 for
+// white.
+func (document *Document) Gray(level float64) {
+	fmt.Fprintf(document.current, "%s g %s G\n", number(level), number(level))
+}
+
+// Line draws a straight line of the given thickness.
+func (document *Document) Line(x1, y1, x2, y2, thickness float64) {
+	fmt.Fprintf(document.current, "%s w %s %s m %s %s l S\n", number(thickness),
+		number(x1), number(document.height-y1), number(x2), number(document.height-y2))
+}
+
+// WriteTo writes the finished document.
+func (document *Document) WriteTo(writer io.Writer) (int64, error) {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1 to 4 are fixed; each page then takes two objects, the page
+	// itself and its content stream.
+	const firstPage = 5
+	kids := new(bytes.Buffer)
+	for i := range document.pages {
+		fmt.Fprintf(kids, "%d 0 R ", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids.String(), len(document.pages)))
+	for _, font := range []Font{Regular, Bold} {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", fontNames[font]))
+	}
+	for i, page := range document.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			number(document.width), number(document.height), firstPage+2*i+1))
+
+		var compressed bytes.Buffer
+		deflate := zlib.NewWriter(&compressed)
+		deflate.Write(page.Bytes())
+		deflate.Close()
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String()))
+	}
+	object(fmt.Sprintf("<< /Title (%s) /Producer (foodpro) >>", escape(document.title)))
+	info := len(offsets)
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, info, xref)
+	return out.WriteTo(writer)
+}
+
+// number formats a coordinate to a hundredth of a point, which is finer
+// than any printer can resolve.
+func number(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// escape encodes text as the body of a PDF literal string in
+// WinAnsiEncoding. Characters the encoding lacks are replaced with "?".
+func escape(text string) string {
+	var out bytes.Buffer
+	for _, r := range text {
+		code := encode(r)
+		switch {
+		case code == '(' || code == ')' || code == '\\':
+			out.WriteByte('\\')
+			out.WriteByte(code)
+		case code < 32 || code > 126:
+			fmt.Fprintf(&out, "\\%03o", code)
+		default:
+			out.WriteByte(code)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,60 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestWriteToOffsets(t *testing.T) {
+	document := NewDocument(A4, "Stew (for two)")
+	for _, text := range []string{"Page one", "Page two"} {
+		document.AddPage()
+		document.Text(72, 72, Bold, 12, text)
+	}
+	var out bytes.Buffer
+	if _, err := document.WriteTo(&out); err != nil {
+		t.Fatal(err)
+	}
+	pdf := out.Bytes()
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(pdf)
+	if match == nil {
+		t.Fatalf("no startxref at the end of:\n%s", pdf)
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(pdf[xref:], -1)
+	// catalog, pages, two fonts, two objects per page and the info
+	if len(entries) != 9 {
+		t.Fatalf("xref has %d objects, want 9", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q, want %q", i+1, pdf[offset:offset+len(want)], want)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := map[string]string{
+		"Stew":            "Stew",
+		"Stew (for two)":  `Stew \(for two\)`,
+		`C:\recipes`:      `C:\\recipes`,
+		"Crème brûlée":    `Cr\350me br\373l\351e`,
+		"5 € – “fresh”":   `5 \200 \226 \223fresh\224`,
+		"寿司 and ramen":    "?? and ramen",
+		"line\nbreak\tab": "line?break?ab",
+	}
+	for text, want := range tests {
+		if got := escape(text); got != want {
+			t.Errorf("escape(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package pdf
+
+// Glyph widths of the printable ASCII characters, space to tilde, in
+// thousandths of the font size, taken from the Adobe font metrics.
+var asciiWidths = map[Font][95]int{
+	Regular: {
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	},
+	Bold: {
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	},
+}
+
+// winAnsi maps the characters WinAnsiEncoding places in 128-159 to their
+// codes. Latin-1 characters from 160 up keep their own code.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+func encode(r rune) byte {
+	if r >= 32 && r <= 126 || r >= 160 && r <= 255 {
+		return byte(r)
+	}
+	if code, ok := winAnsi[r]; ok {
+		return code
+	}
+	return '?'
+}
+
+// Width returns how wide text is when set in font at size points.
+// Characters outside ASCII are measured as an average letter.
+func Width(text string, font Font, size float64) float64 {
+	widths := asciiWidths[font]
+	total := 0
+	for _, r := range text {
+		if code := encode(r); code >= 32 && code <= 126 {
+			total += widths[code-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
@@ -0,0 +1,150 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Paper is a page size in points.
+type Paper struct {
+	Name          string
+	Width, Height float64
+}
+
+var (
+	A4     = Paper{Name: "a4", Width: 595.28, Height: 841.89}
+	Letter = Paper{Name: "letter", Width: 612, Height: 792}
+)
+
+// PaperByName looks up a paper size by its lower case name.
+func PaperByName(name string) (Paper, bool) {
+	for _, paper := range []Paper{A4, Letter} {
+		if strings.EqualFold(name, paper.Name) {
+			return paper, true
+		}
+	}
+	return Paper{}, false
+}
+
+// Options control how recipes are laid out.
+type Options struct {
+	Paper Paper
+	// IngredientColumns is 1 or 2. Two columns suit long ingredient lists.
+	IngredientColumns int
+}
+
+const (
+	margin     = 56
+	footerSize = 8
+	lineHeight = 1.3
+)
+
+// layout flows content down the page, starting new pages as it runs out
+// of room. y is the top of the next line.
+type layout struct {
+	document *Document
+	options  Options
+	width    float64
+	y        float64
+}
+
+func newLayout(options Options, title string) *layout {
+	if options.Paper.Width == 0 {
+		options.Paper = A4
+	}
+	if options.IngredientColumns != 2 {
+		options.IngredientColumns = 1
+	}
+	return &layout{
+		document: NewDocument(options.Paper, title),
+		options:  options,
+		width:    options.Paper.Width - 2*margin,
+	}
+}
+
+func (layout *layout) newPage() {
+	layout.document.AddPage()
+	layout.y = margin
+}
+
+// ensure starts a new page unless height points still fit on this one.
+func (layout *layout) ensure(height float64) {
+	if layout.document.PageCount() == 0 || layout.y+height > layout.options.Paper.Height-margin {
+		layout.newPage()
+	}
+}
+
+func (layout *layout) space(height float64) {
+	layout.y += height
+}
+
+// paragraph writes text wrapped to width, starting at x.
+func (layout *layout) paragraph(x, width float64, font Font, size float64, text string) {
+	for _, line := range wrap(text, width, font, size) {
+		layout.ensure(size * lineHeight)
+		layout.y += size * lineHeight
+		layout.document.Text(x, layout.y-size*(lineHeight-1), font, size, line)
+	}
+}
+
+func (layout *layout) heading(text string, size float64) {
+	layout.ensure(size*lineHeight*2 + 20)
+	layout.paragraph(margin, layout.width, Bold, size, text)
+	layout.space(size * 0.4)
+}
+
+func (layout *layout) rule() {
+	layout.ensure(12)
+	layout.space(4)
+	layout.document.Gray(0.7)
+	layout.document.Line(margin, layout.y, margin+layout.width, layout.y, 0.5)
+	layout.document.Gray(0)
+	layout.space(10)
+}
+
+// footers numbers every page once all content has been laid out.
+func (layout *layout) footers(label string) {
+	total := layout.document.PageCount()
+	bottom := layout.options.Paper.Height - margin/2
+	for page := 1; page <= total; page++ {
+		layout.document.SetPage(page)
+		layout.document.Gray(0.4)
+		layout.document.Text(margin, bottom, Regular, footerSize, label)
+		count := strconv.Itoa(page) + " / " + strconv.Itoa(total)
+		layout.document.Text(margin+layout.width-Width(count, Regular, footerSize), bottom, Regular, footerSize, count)
+		layout.document.Gray(0)
+	}
+}
+
+// wrap breaks text into lines no wider than width, splitting on spaces
+// and breaking words that are too long for a line on their own.
+func wrap(text string, width float64, font Font, size float64) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if Width(candidate, font, size) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = word
+		for Width(line, font, size) > width {
+			cut := len([]rune(line)) - 1
+			for cut > 1 && Width(string([]rune(line)[:cut]), font, size) > width {
+				cut--
+			}
+			lines = append(lines, string([]rune(line)[:cut]))
+			line = string([]rune(line)[cut:])
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,40 @@
+package pdf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	// "a" is 5.56 points wide at 10 points, so three fit in 20 points
+	tests := []struct {
+		text  string
+		width float64
+		want  []string
+	}{
+		{"", 20, []string{""}},
+		{"a a a", 100, []string{"a a a"}},
+		{"aa aa aa", 20, []string{"aa", "aa", "aa"}},
+		{"aaaaaaaaaa", 20, []string{"aaa", "aaa", "aaa", "a"}},
+		{"a aaaaaaa a", 20, []string{"a", "aaa", "aaa", "a a"}},
+	}
+	for _, test := range tests {
+		if got := wrap(test.text, test.width, Regular, 10); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("wrap(%q, %v) = %q, want %q", test.text, test.width, got, test.want)
+		}
+	}
+}
+
+func TestWrapFitsLongWords(t *testing.T) {
+	word := strings.Repeat("Worcestershire", 5)
+	lines := wrap("Add "+word+" sauce", 100, Bold, 11)
+	for _, line := range lines {
+		if Width(line, Bold, 11) > 100 {
+			t.Errorf("line %q is wider than 100 points", line)
+		}
+	}
+	if got := strings.ReplaceAll(strings.Join(lines, ""), " ", ""); got != "Add"+word+"sauce" {
+		t.Errorf("wrap lost text: %q", lines)
+	}
+}
@@ -0,0 +1,191 @@
+package pdf
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tolopsy/foodpro/api/mealplan"
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// RenderRecipe writes a single recipe.
+func RenderRecipe(writer io.Writer, recipe persistence.Recipe, options Options) error {
+	layout := newLayout(options, recipe.Name)
+	layout.recipe(recipe)
+	layout.footers(recipe.Name)
+	_, err := layout.document.WriteTo(writer)
+	return err
+}
+
+// RenderCollection writes a contents page followed by each recipe of the
+// collection, in order, on a page of its own.
+func RenderCollection(writer io.Writer, collection persistence.Collection, recipes []persistence.Recipe, options Options) error {
+	layout := newLayout(options, collection.Name)
+	layout.newPage()
+	layout.heading(collection.Name, 24)
+	if collection.Description != "" {
+		layout.paragraph(margin, layout.width, Regular, 11, collection.Description)
+	}
+	layout.rule()
+	for i, recipe := range recipes {
+		layout.paragraph(margin, layout.width, Regular, 12, strconv.Itoa(i+1)+". "+recipe.Name)
+	}
+
+	for _, recipe := range recipes {
+		layout.newPage()
+		layout.recipe(recipe)
+	}
+	layout.footers(collection.Name)
+	_, err := layout.document.WriteTo(writer)
+	return err
+}
+
+var slotOrder = map[string]int{
+	persistence.Breakfast: 0,
+	persistence.Lunch:     1,
+	persistence.Snack:     2,
+	persistence.Dinner:    3,
+}
+
+// RenderMealPlan writes the plan's schedule day by day, followed by every
+// recipe it uses once, in the order they first appear. recipes may be in
+// any order; entries whose recipe is missing are listed but not printed.
+func RenderMealPlan(writer io.Writer, plan persistence.MealPlan, recipes []persistence.Recipe, options Options) error {
+	byID := make(map[string]persistence.Recipe, len(recipes))
+	for _, recipe := range recipes {
+		byID[recipe.IDString()] = recipe
+	}
+
+	entries := append([]persistence.MealPlanEntry(nil), plan.Entries...)
+	sortEntries(entries)
+
+	layout := newLayout(options, plan.Name)
+	layout.newPage()
+	layout.heading(plan.Name, 24)
+	layout.rule()
+	date := ""
+	for _, entry := range entries {
+		if entry.Date != date {
+			date = entry.Date
+			layout.space(6)
+			layout.heading(formatDate(date), 13)
+		}
+		name := entry.RecipeName
+		if recipe, ok := byID[entry.RecipeID]; ok {
+			name = recipe.Name
+		}
+		if entry.Servings > 0 {
+			name += " (serves " + strconv.Itoa(entry.Servings) + ")"
+		}
+		slot := strings.ToUpper(entry.Slot[:1]) + entry.Slot[1:]
+		layout.ensure(11 * lineHeight)
+		top := layout.y
+		layout.paragraph(margin, 80, Bold, 11, slot)
+		layout.y = top
+		layout.paragraph(margin+80, layout.width-80, Regular, 11, name)
+	}
+
+	printed := make(map[string]bool)
+	for _, entry := range entries {
+		recipe, ok := byID[entry.RecipeID]
+		if !ok || printed[entry.RecipeID] {
+			continue
+		}
+		printed[entry.RecipeID] = true
+		layout.newPage()
+		layout.recipe(recipe)
+	}
+	layout.footers(plan.Name)
+	_, err := layout.document.WriteTo(writer)
+	return err
+}
+
+func (layout *layout) recipe(recipe persistence.Recipe) {
+	layout.heading(recipe.Name, 20)
+	var details []string
+	if recipe.Servings > 0 {
+		details = append(details, "Serves "+strconv.Itoa(recipe.Servings))
+	}
+	if len(recipe.Tags) > 0 {
+		details = append(details, strings.Join(recipe.Tags, ", "))
+	}
+	if len(details) > 0 {
+		layout.document.Gray(0.4)
+		layout.paragraph(margin, layout.width, Regular, 10, strings.Join(details, "  •  "))
+		layout.document.Gray(0)
+	}
+	layout.rule()
+
+	layout.heading("Ingredients", 13)
+	layout.ingredients(recipe.Ingredients)
+	layout.space(12)
+
+	layout.heading("Method", 13)
+	const indent = 22
+	for i, instruction := range recipe.Instructions {
+		layout.ensure(11 * lineHeight)
+		top := layout.y
+		layout.paragraph(margin, indent, Bold, 11, strconv.Itoa(i+1)+".")
+		layout.y = top
+		layout.paragraph(margin+indent, layout.width-indent, Regular, 11, instruction)
+		layout.space(5)
+	}
+}
+
+// ingredients lists ingredients down one column, or down the left column
+// and then the right when two are requested. Rows of the two columns are
+// kept level so a long ingredient never overlaps the next row.
+func (layout *layout) ingredients(ingredients []persistence.Ingredient) {
+	const size, bullet, gutter = 11, 12, 18
+	columns := layout.options.IngredientColumns
+	columnWidth := (layout.width - float64(columns-1)*gutter) / float64(columns)
+	rows := (len(ingredients) + columns - 1) / columns
+
+	for row := 0; row < rows; row++ {
+		var cells [][]string
+		height := 0
+		for column := 0; column < columns; column++ {
+			index := column*rows + row
+			if index >= len(ingredients) {
+				break
+			}
+			lines := wrap(ingredients[index].String(), columnWidth-bullet, Regular, size)
+			cells = append(cells, lines)
+			if len(lines) > height {
+				height = len(lines)
+			}
+		}
+
+		layout.ensure(float64(height) * size * lineHeight)
+		top := layout.y
+		for column, lines := range cells {
+			x := margin + float64(column)*(columnWidth+gutter)
+			layout.document.Text(x, top+size, Regular, size, "•")
+			for i, line := range lines {
+				layout.document.Text(x+bullet, top+size+float64(i)*size*lineHeight, Regular, size, line)
+			}
+		}
+		layout.y = top + float64(height)*size*lineHeight + 2
+	}
+}
+
+// sortEntries orders entries by date and then by meal slot through the day.
+func sortEntries(entries []persistence.MealPlanEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Date != entries[j].Date {
+			return entries[i].Date < entries[j].Date
+		}
+		return slotOrder[entries[i].Slot] < slotOrder[entries[j].Slot]
+	})
+}
+
+func formatDate(date string) string {
+	day, err := time.Parse(mealplan.DateLayout, date)
+	if err != nil {
+		return date
+	}
+	return day.Format("Monday 2 January 2006")
+}
@@ -0,0 +1,20 @@
+package persistence
+
+import "time"
+
+type Favorite struct {
+	Username  string    `json:"username" bson:"username"`
+	RecipeID  string    `json:"recipeId" bson:"recipeId"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// CookingLog records a user cooking a recipe. Photo is a reference to an
+// image stored elsewhere, such as a URL.
+type CookingLog struct {
+	ID       interface{} `json:"id,omitempty" bson:"_id,omitempty"`
+	Username string      `json:"username" bson:"username"`
+	RecipeID string      `json:"recipeId" bson:"recipeId"`
+	Note     string      `json:"note,omitempty" bson:"note,omitempty"`
+	Photo    string      `json:"photo,omitempty" bson:"photo,omitempty"`
+	CookedAt time.Time   `json:"cookedAt" bson:"cookedAt"`
+}
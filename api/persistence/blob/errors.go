@@ -0,0 +1,7 @@
+package blob
+
+import "errors"
+
+var ErrorBlobStorePluginDoesNotExist = errors.New("required blob store plugin does not exist")
+var ErrorBlobDoesNotExist = errors.New("blob does not exist")
+var ErrorInvalidKey = errors.New("invalid blob key")
@@ -0,0 +1,14 @@
+package blob
+
+import "github.com/tolopsy/foodpro/api/persistence"
+
+// DeleteImages removes every stored rendition of images from store. A blob
+// that cannot be deleted is left behind rather than failing the request
+// that dropped the image.
+func DeleteImages(store persistence.BlobStore, images []persistence.RecipeImage) {
+	for _, image := range images {
+		for _, key := range image.Keys {
+			store.Delete(key)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package localfs
+
+import (
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/persistence/blob"
+)
+
+// BlobStore keeps blobs as files below a root directory and serves them
+// under baseURL.
+type BlobStore struct {
+	root    string
+	baseURL string
+}
+
+func NewBlobStore(root, baseURL string) (*BlobStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &BlobStore{root: root, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// path maps a key to a file below root, refusing keys that would escape it.
+func (store *BlobStore) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || cleaned != "/"+key {
+		return "", blob.ErrorInvalidKey
+	}
+	return filepath.Join(store.root, filepath.FromSlash(cleaned)), nil
+}
+
+// Put writes to a temporary file first so readers never see a partial blob.
+func (store *BlobStore) Put(key string, data io.Reader, contentType string) error {
+	target, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = io.Copy(file, data); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), target)
+}
+
+func (store *BlobStore) Get(key string) (io.ReadCloser, string, error) {
+	target, err := store.path(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := os.Open(target)
+	if os.IsNotExist(err) {
+		return nil, "", blob.ErrorBlobDoesNotExist
+	} else if err != nil {
+		return nil, "", err
+	}
+	return file, mime.TypeByExtension(filepath.Ext(target)), nil
+}
+
+func (store *BlobStore) Delete(key string) error {
+	target, err := store.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (store *BlobStore) URL(key string) string {
+	return store.baseURL + "/" + key
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"log/slog"
+
+	"github.com/tolopsy/foodpro/api/metrics"
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// Recipes reads every recipe from recipeCache, falling back to database and
+// refilling the cache on a miss. Cache failures are logged to log and
+// counted, but only a database failure is returned. Both handlers should
+// already be bound to the request's context.
+func Recipes(database persistence.DatabaseHandler, recipeCache persistence.CacheHandler, log *slog.Logger) ([]persistence.Recipe, error) {
+	recipes, err := recipeCache.GetRecipes()
+	if err == ErrorKeyDoesNotExist {
+		metrics.ObserveCache("recipes", metrics.CacheMiss)
+	} else if err != nil {
+		metrics.ObserveCache("recipes", metrics.CacheError)
+		log.Warn("Error while fetching recipes from cache", slog.String("error", err.Error()))
+	} else {
+		metrics.ObserveCache("recipes", metrics.CacheHit)
+		return recipes, nil
+	}
+
+	if recipes, err = database.FetchAllRecipes(); err != nil {
+		return nil, err
+	}
+	if err := recipeCache.SetRecipes(recipes); err != nil {
+		log.Warn("Error while caching recipes", slog.String("error", err.Error()))
+	}
+	return recipes, nil
+}
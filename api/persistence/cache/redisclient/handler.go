@@ -1,7 +1,10 @@
 package redisclient
 
 import (
+	"context"
 	"encoding/json"
+	"log/slog"
+	"strings"
 
 	"github.com/go-redis/redis"
 	"github.com/tolopsy/foodpro/api/persistence"
@@ -11,9 +14,11 @@ import (
 type CacheHandler struct {
 	client    *redis.Client
 	recipeKey string
+	logger    *slog.Logger
 }
 
-func NewCacheHandler(host, password string) (*CacheHandler, error) {
+func NewCacheHandler(host, password string, logger *slog.Logger) (*CacheHandler, error) {
+	logger = logger.With(slog.String("component", "redis"))
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     host,
 		Password: password,
@@ -24,7 +29,8 @@ func NewCacheHandler(host, password string) (*CacheHandler, error) {
 		return nil, err
 	}
 
-	return &CacheHandler{client: redisClient, recipeKey: "recipes"}, nil
+	logger.Info("Connected to cache", slog.String("host", host))
+	return &CacheHandler{client: redisClient, recipeKey: "recipes", logger: logger}, nil
 }
 
 func (handler *CacheHandler) SetRecipes(recipes []persistence.Recipe) error {
@@ -47,6 +53,7 @@ func (handler *CacheHandler) GetRecipes() ([]persistence.Recipe, error) {
 	recipes := make([]persistence.Recipe, 0)
 	err = json.Unmarshal([]byte(value), &recipes)
 	if err != nil {
+		handler.logger.Warn("Cached recipes are unreadable", slog.String("key", handler.recipeKey), slog.String("error", err.Error()))
 		return nil, err
 	}
 
@@ -55,4 +62,33 @@ func (handler *CacheHandler) GetRecipes() ([]persistence.Recipe, error) {
 
 func (handler *CacheHandler) ClearRecipes() error {
 	return handler.client.Del("recipes").Err()
-}
\ No newline at end of file
+}
+// WithContext returns a copy of the handler whose commands run under ctx.
+func (handler *CacheHandler) WithContext(ctx context.Context) persistence.CacheHandler {
+	bound := *handler
+	bound.client = handler.client.WithContext(ctx)
+	return &bound
+}
+
+func (handler *CacheHandler) Ping(ctx context.Context) error {
+	return handler.client.WithContext(ctx).Ping().Err()
+}
+
+// ServerVersion reads redis_version from the server section of INFO.
+func (handler *CacheHandler) ServerVersion(ctx context.Context) (string, error) {
+	info, err := handler.client.WithContext(ctx).Info("server").Result()
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(info, "\n") {
+		if strings.HasPrefix(line, "redis_version:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "redis_version:")), nil
+		}
+	}
+	return "", nil
+}
+
+func (handler *CacheHandler) Close() error {
+	handler.logger.Info("Disconnecting from cache")
+	return handler.client.Close()
+}
@@ -0,0 +1,43 @@
+package persistence
+
+import "time"
+
+// Collection visibilities. Shared collections are readable by the users in
+// SharedWith, public ones by everyone.
+const (
+	Private = "private"
+	Shared  = "shared"
+	Public  = "public"
+)
+
+type Collection struct {
+	ID          interface{} `json:"id,omitempty" bson:"_id,omitempty"`
+	Owner       string      `json:"owner" bson:"owner"`
+	Name        string      `json:"name" bson:"name"`
+	Description string      `json:"description,omitempty" bson:"description,omitempty"`
+	Visibility  string      `json:"visibility" bson:"visibility"`
+	SharedWith  []string    `json:"sharedWith,omitempty" bson:"sharedWith,omitempty"`
+	// ordered as the owner arranged them
+	RecipeIDs []string  `json:"recipeIds" bson:"recipeIds"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// ReadableBy reports whether username may view the collection. An empty
+// username stands for an anonymous visitor.
+func (collection Collection) ReadableBy(username string) bool {
+	switch {
+	case collection.Visibility == Public:
+		return true
+	case username == "":
+		return false
+	case collection.Owner == username:
+		return true
+	case collection.Visibility == Shared:
+		for _, user := range collection.SharedWith {
+			if user == username {
+				return true
+			}
+		}
+	}
+	return false
+}
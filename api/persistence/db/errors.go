@@ -2,4 +2,6 @@ package db
 
 import "errors"
 
-var ErrorDBPluginDoesNotExist = errors.New("required database plugin does not exist")
\ No newline at end of file
+var ErrorDBPluginDoesNotExist = errors.New("required database plugin does not exist")
+var ErrorVersionMismatch = errors.New("recipe version does not match the expected version")
+var ErrorNotFound = errors.New("requested document does not exist")
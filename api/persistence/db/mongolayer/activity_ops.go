@@ -0,0 +1,109 @@
+package mongolayer
+
+import (
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	db_errors "github.com/tolopsy/foodpro/api/persistence/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddFavorite is idempotent; the recipe's favorite count only changes the
+// first time a user favorites it, in the same transaction as the favorite.
+func (db *DBHandler) AddFavorite(username, recipeId string) error {
+	objectId, err := primitive.ObjectIDFromHex(recipeId)
+	if err != nil {
+		return err
+	}
+
+	err = db.transaction(func(tx *DBHandler) error {
+		filter := bson.M{"username": username, "recipeId": recipeId}
+		update := bson.M{"$setOnInsert": bson.M{"createdAt": time.Now()}}
+		result, err := tx.favoriteCollection.UpdateOne(tx.context, filter, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+		if result.UpsertedCount == 0 {
+			return nil
+		}
+		return tx.adjustFavoriteCount(objectId, 1)
+	})
+	// a concurrent request by the same user favorited the recipe first
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+func (db *DBHandler) RemoveFavorite(username, recipeId string) error {
+	objectId, err := primitive.ObjectIDFromHex(recipeId)
+	if err != nil {
+		return err
+	}
+
+	return db.transaction(func(tx *DBHandler) error {
+		result, err := tx.favoriteCollection.DeleteOne(tx.context, bson.M{"username": username, "recipeId": recipeId})
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			return db_errors.ErrorNotFound
+		}
+		return tx.adjustFavoriteCount(objectId, -1)
+	})
+}
+
+func (db *DBHandler) FetchFavorites(username string) ([]persistence.Favorite, error) {
+	findOptions := options.Find().SetSort(bson.M{"createdAt": -1})
+	cursor, err := db.favoriteCollection.Find(db.context, bson.M{"username": username}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	favorites := make([]persistence.Favorite, 0)
+	if err = cursor.All(db.context, &favorites); err != nil {
+		return nil, err
+	}
+	return favorites, nil
+}
+
+func (db *DBHandler) AddCookingLog(log *persistence.CookingLog) error {
+	log.ID = primitive.NewObjectID()
+	if log.CookedAt.IsZero() {
+		log.CookedAt = time.Now()
+	}
+	_, err := db.cookingLogCollection.InsertOne(db.context, log)
+	return err
+}
+
+func (db *DBHandler) FetchCookingHistory(username string, skip, limit int) ([]persistence.CookingLog, int, error) {
+	filter := bson.M{"username": username}
+	total, err := db.cookingLogCollection.CountDocuments(db.context, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.M{"cookedAt": -1}).
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit))
+	cursor, err := db.cookingLogCollection.Find(db.context, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	logs := make([]persistence.CookingLog, 0)
+	if err = cursor.All(db.context, &logs); err != nil {
+		return nil, 0, err
+	}
+	return logs, int(total), nil
+}
+
+func (db *DBHandler) adjustFavoriteCount(recipeId primitive.ObjectID, delta int) error {
+	update := bson.M{"$inc": bson.M{"favoriteCount": delta}}
+	_, err := db.recipeCollection.UpdateByID(db.context, recipeId, update)
+	return err
+}
@@ -0,0 +1,129 @@
+package mongolayer
+
+import (
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	db_errors "github.com/tolopsy/foodpro/api/persistence/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (db *DBHandler) AddCollection(collection *persistence.Collection) error {
+	collection.ID = primitive.NewObjectID()
+	collection.CreatedAt = time.Now()
+	if collection.RecipeIDs == nil {
+		collection.RecipeIDs = []string{}
+	}
+	_, err := db.collectionCollection.InsertOne(db.context, collection)
+	return err
+}
+
+// FetchCollections lists the collections a user owns or has been given
+// access to.
+func (db *DBHandler) FetchCollections(username string) ([]persistence.Collection, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"owner": username},
+		bson.M{"visibility": persistence.Shared, "sharedWith": username},
+	}}
+	findOptions := options.Find().SetSort(bson.M{"createdAt": -1})
+	cursor, err := db.collectionCollection.Find(db.context, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]persistence.Collection, 0)
+	if err = cursor.All(db.context, &collections); err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (db *DBHandler) GetCollection(id string) (persistence.Collection, error) {
+	var collection persistence.Collection
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return collection, err
+	}
+
+	result := db.collectionCollection.FindOne(db.context, bson.M{"_id": objectId})
+	if err = result.Decode(&collection); err == mongo.ErrNoDocuments {
+		return collection, db_errors.ErrorNotFound
+	} else if err != nil {
+		return collection, err
+	}
+	return collection, nil
+}
+
+func (db *DBHandler) UpdateCollection(owner, id string, collection persistence.Collection) error {
+	update := bson.M{"$set": bson.M{
+		"name":        collection.Name,
+		"description": collection.Description,
+		"visibility":  collection.Visibility,
+		"sharedWith":  collection.SharedWith,
+	}}
+	return db.updateOwnedCollection(owner, id, bson.M{}, update)
+}
+
+// AddCollectionRecipe inserts a recipe at position, or appends it when
+// position is negative. Recipes already in the collection are left alone.
+func (db *DBHandler) AddCollectionRecipe(owner, id, recipeId string, position int) error {
+	push := bson.M{"$each": bson.A{recipeId}}
+	if position >= 0 {
+		push["$position"] = position
+	}
+	filter := bson.M{"recipeIds": bson.M{"$ne": recipeId}}
+	return db.updateOwnedCollection(owner, id, filter, bson.M{"$push": bson.M{"recipeIds": push}})
+}
+
+func (db *DBHandler) RemoveCollectionRecipe(owner, id, recipeId string) error {
+	return db.updateOwnedCollection(owner, id, bson.M{}, bson.M{"$pull": bson.M{"recipeIds": recipeId}})
+}
+
+// ReorderCollection replaces the order of recipes. recipeIds must hold
+// exactly the recipes already in the collection; the filter guards against
+// a recipe being added or removed in the meantime.
+func (db *DBHandler) ReorderCollection(owner, id string, recipeIds []string) error {
+	filter := bson.M{
+		"recipeIds": bson.M{"$all": recipeIds, "$size": len(recipeIds)},
+	}
+	return db.updateOwnedCollection(owner, id, filter, bson.M{"$set": bson.M{"recipeIds": recipeIds}})
+}
+
+func (db *DBHandler) DeleteCollection(owner, id string) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.collectionCollection.DeleteOne(db.context, bson.M{"_id": objectId, "owner": owner})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return db_errors.ErrorNotFound
+	}
+	return nil
+}
+
+// updateOwnedCollection applies update to the owner's collection if it also
+// matches filter, and reports ErrorNotFound otherwise.
+func (db *DBHandler) updateOwnedCollection(owner, id string, filter, update bson.M) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	filter["_id"] = objectId
+	filter["owner"] = owner
+	result, err := db.collectionCollection.UpdateOne(db.context, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db_errors.ErrorNotFound
+	}
+	return nil
+}
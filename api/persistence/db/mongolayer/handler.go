@@ -2,20 +2,42 @@ package mongolayer
 
 import (
 	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 type DBHandler struct {
-	recipeCollection *mongo.Collection
-	userCollection   *mongo.Collection
-	context          context.Context
+	client                 *mongo.Client
+	recipeCollection       *mongo.Collection
+	userCollection         *mongo.Collection
+	shoppingListCollection *mongo.Collection
+	mealPlanCollection     *mongo.Collection
+	collectionCollection   *mongo.Collection
+	reviewCollection       *mongo.Collection
+	favoriteCollection     *mongo.Collection
+	cookingLogCollection   *mongo.Collection
+	context                context.Context
+	logger                 *slog.Logger
+	// whether the deployment is a replica set or sharded cluster, which
+	// multi-document transactions need
+	transactions bool
 }
 
-func NewMongoDBHandler(dbURI, dbName string) (*DBHandler, error) {
+// slowCommand is how long a command may take before it is logged.
+const slowCommand = 500 * time.Millisecond
+
+func NewMongoDBHandler(dbURI, dbName string, logger *slog.Logger) (*DBHandler, error) {
 	ctx := context.Background()
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(dbURI))
+	logger = logger.With(slog.String("component", "mongodb"))
+	clientOptions := options.Client().ApplyURI(dbURI).SetMonitor(commandMonitor(logger))
+	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -25,10 +47,133 @@ func NewMongoDBHandler(dbURI, dbName string) (*DBHandler, error) {
 
 	recipeCollection := client.Database(dbName).Collection("recipes")
 	userCollection := client.Database(dbName).Collection("users")
+	shoppingListCollection := client.Database(dbName).Collection("shopping_lists")
+	mealPlanCollection := client.Database(dbName).Collection("meal_plans")
+	collectionCollection := client.Database(dbName).Collection("collections")
+	reviewCollection := client.Database(dbName).Collection("reviews")
+	favoriteCollection := client.Database(dbName).Collection("favorites")
+	cookingLogCollection := client.Database(dbName).Collection("cooking_logs")
+
+	transactions, err := supportsTransactions(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if !transactions {
+		logger.Warn("MongoDB is not a replica set, so related writes are made without transactions")
+	}
+
+	handler := &DBHandler{
+		client:                 client,
+		recipeCollection:       recipeCollection,
+		userCollection:         userCollection,
+		shoppingListCollection: shoppingListCollection,
+		mealPlanCollection:     mealPlanCollection,
+		collectionCollection:   collectionCollection,
+		reviewCollection:       reviewCollection,
+		favoriteCollection:     favoriteCollection,
+		cookingLogCollection:   cookingLogCollection,
+		context:                ctx,
+		logger:                 logger,
+		transactions:           transactions,
+	}
+	if err = handler.migrateIngredients(); err != nil {
+		return nil, err
+	}
+	if err = handler.classifyRecipes(); err != nil {
+		return nil, err
+	}
+	if err = handler.createIndexes(); err != nil {
+		return nil, err
+	}
+	logger.Info("Connected to database", slog.String("database", dbName))
+	return handler, nil
+}
+
+// supportsTransactions reports whether the server is part of a replica set
+// or is a mongos router. A standalone server cannot run transactions.
+func supportsTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&hello)
+	return hello.SetName != "" || hello.Msg == "isdbgrid", err
+}
+
+// transaction runs fn on a copy of the handler bound to a new transaction,
+// so the writes fn makes through it are applied together or not at all. The
+// driver runs fn again on transient errors such as write conflicts, so fn
+// must be safe to repeat. On a standalone server, which cannot run
+// transactions, fn runs directly and each of its writes stands alone.
+func (db *DBHandler) transaction(fn func(tx *DBHandler) error) error {
+	if !db.transactions {
+		return fn(db)
+	}
+	session, err := db.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(db.context)
+
+	_, err = session.WithTransaction(db.context, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		tx := *db
+		tx.context = sessionContext
+		return nil, fn(&tx)
+	})
+	return err
+}
+
+// commandMonitor logs failed and slow commands. Successful commands are only
+// logged at debug level.
+func commandMonitor(logger *slog.Logger) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(ctx context.Context, succeeded *event.CommandSucceededEvent) {
+			duration := time.Duration(succeeded.DurationNanos)
+			level := slog.LevelDebug
+			if duration >= slowCommand {
+				level = slog.LevelWarn
+			}
+			logger.LogAttrs(ctx, level, "Database command finished",
+				slog.String("command", succeeded.CommandName),
+				slog.Duration("duration", duration),
+			)
+		},
+		Failed: func(ctx context.Context, failed *event.CommandFailedEvent) {
+			logger.LogAttrs(ctx, slog.LevelWarn, "Database command failed",
+				slog.String("command", failed.CommandName),
+				slog.Duration("duration", time.Duration(failed.DurationNanos)),
+				slog.String("error", failed.Failure),
+			)
+		},
+	}
+}
+
+// WithContext returns a copy of the handler whose operations use ctx in place
+// of the background context.
+func (db *DBHandler) WithContext(ctx context.Context) persistence.DatabaseHandler {
+	bound := *db
+	bound.context = ctx
+	return &bound
+}
+
+func (db *DBHandler) Ping(ctx context.Context) error {
+	return db.client.Ping(ctx, readpref.Primary())
+}
+
+func (db *DBHandler) ServerVersion(ctx context.Context) (string, error) {
+	var info struct {
+		Version string `bson:"version"`
+	}
+	err := db.client.Database("admin").RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&info)
+	return info.Version, err
+}
+
+// closeTimeout bounds how long Close waits for in-flight operations.
+const closeTimeout = 10 * time.Second
 
-	return &DBHandler{
-		recipeCollection: recipeCollection,
-		userCollection:   userCollection,
-		context:          ctx,
-	}, nil
+func (db *DBHandler) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	db.logger.Info("Disconnecting from database")
+	return db.client.Disconnect(ctx)
 }
@@ -0,0 +1,82 @@
+package mongolayer
+
+import (
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	db_errors "github.com/tolopsy/foodpro/api/persistence/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (db *DBHandler) AddMealPlan(plan *persistence.MealPlan) error {
+	plan.ID = primitive.NewObjectID()
+	plan.CreatedAt = time.Now()
+	_, err := db.mealPlanCollection.InsertOne(db.context, plan)
+	return err
+}
+
+func (db *DBHandler) FetchMealPlans(owner string) ([]persistence.MealPlan, error) {
+	findOptions := options.Find().SetSort(bson.M{"createdAt": -1})
+	cursor, err := db.mealPlanCollection.Find(db.context, bson.M{"owner": owner}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]persistence.MealPlan, 0)
+	if err = cursor.All(db.context, &plans); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+func (db *DBHandler) GetMealPlan(owner, id string) (persistence.MealPlan, error) {
+	var plan persistence.MealPlan
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return plan, err
+	}
+
+	result := db.mealPlanCollection.FindOne(db.context, bson.M{"_id": objectId, "owner": owner})
+	if err = result.Decode(&plan); err == mongo.ErrNoDocuments {
+		return plan, db_errors.ErrorNotFound
+	} else if err != nil {
+		return plan, err
+	}
+	return plan, nil
+}
+
+func (db *DBHandler) UpdateMealPlan(owner, id string, plan persistence.MealPlan) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"name": plan.Name, "entries": plan.Entries}}
+	result, err := db.mealPlanCollection.UpdateOne(db.context, bson.M{"_id": objectId, "owner": owner}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db_errors.ErrorNotFound
+	}
+	return nil
+}
+
+func (db *DBHandler) DeleteMealPlan(owner, id string) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.mealPlanCollection.DeleteOne(db.context, bson.M{"_id": objectId, "owner": owner})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return db_errors.ErrorNotFound
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package mongolayer
+
+import (
+	"log/slog"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// migrateIngredients rewrites recipes whose ingredients are still stored as
+// free-text lines into the structured Ingredient form. It only touches
+// documents that need it, so it is safe to run on every start.
+func (db *DBHandler) migrateIngredients() error {
+	filter := bson.M{"ingredients": bson.M{"$type": "string"}}
+	cursor, err := db.recipeCollection.Find(db.context, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(db.context)
+
+	migrated := 0
+	for cursor.Next(db.context) {
+		// Ingredient decodes string values by parsing them
+		var recipe persistence.Recipe
+		if err = cursor.Decode(&recipe); err != nil {
+			return err
+		}
+
+		update := bson.M{"$set": bson.M{"ingredients": recipe.Ingredients}}
+		if _, err = db.recipeCollection.UpdateByID(db.context, recipe.ID, update); err != nil {
+			return err
+		}
+		migrated++
+	}
+	if migrated > 0 {
+		db.logger.Info("Migrated free-text ingredients", slog.Int("recipes", migrated))
+	}
+	return cursor.Err()
+}
+
+// classifyRecipes derives allergens and diets for recipes saved before
+// classification existed. Empty results are stored as empty arrays so each
+// recipe is only visited once.
+func (db *DBHandler) classifyRecipes() error {
+	filter := bson.M{"diets": bson.M{"$exists": false}}
+	cursor, err := db.recipeCollection.Find(db.context, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(db.context)
+
+	classified := 0
+	for cursor.Next(db.context) {
+		var recipe persistence.Recipe
+		if err = cursor.Decode(&recipe); err != nil {
+			return err
+		}
+
+		allergens, diets := classify(recipe.Ingredients)
+		update := bson.M{"$set": bson.M{"allergens": allergens, "diets": diets}}
+		if _, err = db.recipeCollection.UpdateByID(db.context, recipe.ID, update); err != nil {
+			return err
+		}
+		classified++
+	}
+	if classified > 0 {
+		db.logger.Info("Classified recipes for allergens and diets", slog.Int("recipes", classified))
+	}
+	return cursor.Err()
+}
+
+// createIndexes makes sure the indexes the queries and uniqueness rules
+// rely on exist. Creating an index that already exists is a no-op.
+func (db *DBHandler) createIndexes() error {
+	_, err := db.reviewCollection.Indexes().CreateMany(db.context, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "recipeId", Value: 1}, {Key: "username", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "recipeId", Value: 1}, {Key: "createdAt", Value: -1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.favoriteCollection.Indexes().CreateOne(db.context, mongo.IndexModel{
+		Keys:    bson.D{{Key: "username", Value: 1}, {Key: "recipeId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.cookingLogCollection.Indexes().CreateOne(db.context, mongo.IndexModel{
+		Keys: bson.D{{Key: "username", Value: 1}, {Key: "cookedAt", Value: -1}},
+	})
+	return err
+}
@@ -3,11 +3,33 @@ package mongolayer
 import (
 	"time"
 
+	"github.com/tolopsy/foodpro/api/dietary"
 	"github.com/tolopsy/foodpro/api/persistence"
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	db_errors "github.com/tolopsy/foodpro/api/persistence/db"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// recipeFilter matches a recipe by id and, unless version is
+// persistence.AnyVersion, by its current version. Recipes stored before
+// versioning was introduced have no version field and count as version 0.
+func recipeFilter(objectId primitive.ObjectID, version int64) bson.M {
+	filter := bson.M{"_id": objectId}
+	switch {
+	case version == persistence.AnyVersion:
+	case version == 0:
+		filter["$or"] = bson.A{
+			bson.M{"version": 0},
+			bson.M{"version": bson.M{"$exists": false}},
+		}
+	default:
+		filter["version"] = version
+	}
+	return filter
+}
+
 func (db *DBHandler) FetchAllRecipes() ([]persistence.Recipe, error) {
 	cursor, err := db.recipeCollection.Find(db.context, bson.M{})
 	if err != nil {
@@ -22,25 +44,94 @@ func (db *DBHandler) FetchAllRecipes() ([]persistence.Recipe, error) {
 	return recipes, nil
 }
 
+// EachRecipe calls fn with every recipe in insertion order without loading
+// them all into memory. It stops at the first error fn returns.
+func (db *DBHandler) EachRecipe(fn func(persistence.Recipe) error) error {
+	return db.EachMatchingRecipe(persistence.RecipeFilter{}, fn)
+}
+
+// EachMatchingRecipe is EachRecipe narrowed and ordered by filter.
+func (db *DBHandler) EachMatchingRecipe(filter persistence.RecipeFilter, fn func(persistence.Recipe) error) error {
+	searchArg, findOptions := recipeSearch(filter)
+	cursor, err := db.recipeCollection.Find(db.context, searchArg, findOptions)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(db.context)
+
+	for cursor.Next(db.context) {
+		var recipe persistence.Recipe
+		if err := cursor.Decode(&recipe); err != nil {
+			return err
+		}
+		if err := fn(recipe); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// GetRecipe reports db_errors.ErrorNotFound when no recipe has id,
+// including when id is malformed.
 func (db *DBHandler) GetRecipe(id string) (persistence.Recipe, error) {
 	var recipe persistence.Recipe
 	objectId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return recipe, err
+		return recipe, db_errors.ErrorNotFound
 	}
 
 	documentArg := bson.M{"_id": objectId}
 	result := db.recipeCollection.FindOne(db.context, documentArg)
 
-	if err = result.Decode(&recipe); err != nil {
+	if err = result.Decode(&recipe); err == mongo.ErrNoDocuments {
+		return recipe, db_errors.ErrorNotFound
+	} else if err != nil {
 		return recipe, err
 	}
 	return recipe, nil
 }
 
+// FetchRecipesByIDs returns the recipes in the order of ids. Ids that are
+// malformed or no longer exist are skipped.
+func (db *DBHandler) FetchRecipesByIDs(ids []string) ([]persistence.Recipe, error) {
+	objectIds := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objectId, err := primitive.ObjectIDFromHex(id); err == nil {
+			objectIds = append(objectIds, objectId)
+		}
+	}
+
+	cursor, err := db.recipeCollection.Find(db.context, bson.M{"_id": bson.M{"$in": objectIds}})
+	if err != nil {
+		return nil, err
+	}
+	var found []persistence.Recipe
+	if err = cursor.All(db.context, &found); err != nil {
+		return nil, err
+	}
+
+	byId := make(map[primitive.ObjectID]persistence.Recipe, len(found))
+	for _, recipe := range found {
+		if objectId, ok := recipe.ID.(primitive.ObjectID); ok {
+			byId[objectId] = recipe
+		}
+	}
+	recipes := make([]persistence.Recipe, 0, len(found))
+	for _, objectId := range objectIds {
+		if recipe, ok := byId[objectId]; ok {
+			recipes = append(recipes, recipe)
+		}
+	}
+	return recipes, nil
+}
+
 func (db *DBHandler) FindRecipesByTag(tag string) ([]persistence.Recipe, error) {
-	searchArg := bson.M{"tags": tag}
-	cursor, err := db.recipeCollection.Find(db.context, searchArg)
+	return db.SearchRecipes(persistence.RecipeFilter{Tag: tag})
+}
+
+func (db *DBHandler) SearchRecipes(filter persistence.RecipeFilter) ([]persistence.Recipe, error) {
+	searchArg, findOptions := recipeSearch(filter)
+	cursor, err := db.recipeCollection.Find(db.context, searchArg, findOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -52,40 +143,217 @@ func (db *DBHandler) FindRecipesByTag(tag string) ([]persistence.Recipe, error)
 	return recipes, nil
 }
 
-func (db *DBHandler) AddRecipe(recipe *persistence.Recipe) error {
+// recipeSearch builds the query and ordering for filter. Recipes come in
+// insertion order unless filter asks for them by rating, where unrated
+// recipes, which have no rating fields, sort last.
+func recipeSearch(filter persistence.RecipeFilter) (bson.M, *options.FindOptions) {
+	searchArg := bson.M{}
+	if filter.Tag != "" {
+		searchArg["tags"] = filter.Tag
+	}
+	if len(filter.Diets) > 0 {
+		searchArg["diets"] = bson.M{"$all": filter.Diets}
+	}
+	if len(filter.ExcludeAllergens) > 0 {
+		searchArg["allergens"] = bson.M{"$nin": filter.ExcludeAllergens}
+	}
+
+	order := bson.D{{Key: "_id", Value: 1}}
+	if filter.ByRating {
+		order = bson.D{{Key: "ratingAverage", Value: -1}, {Key: "ratingCount", Value: -1}, {Key: "_id", Value: 1}}
+	}
+	return searchArg, options.Find().SetSort(order)
+}
+
+// prepareNewRecipe sets the fields the database owns on a recipe about to
+// be inserted.
+func prepareNewRecipe(recipe *persistence.Recipe) {
 	recipe.ID = primitive.NewObjectID()
-	recipe.PublishedAt = time.Now()
-	_, err := db.recipeCollection.InsertOne(db.context, recipe)
+	if recipe.PublishedAt.IsZero() {
+		recipe.PublishedAt = time.Now()
+	}
+	recipe.Version = 1
+	recipe.RatingAverage, recipe.RatingCount, recipe.RatingSum = 0, 0, 0
+	recipe.FavoriteCount = 0
+	recipe.Images = nil
+	recipe.Allergens, recipe.Diets = classify(recipe.Ingredients)
+}
+
+// newRecipeDocument prepares recipe for insertion and returns the document
+// to insert. Allergens and diets are written even when empty, which the
+// omitempty tags on Recipe would otherwise prevent.
+func newRecipeDocument(recipe *persistence.Recipe) (bson.M, error) {
+	prepareNewRecipe(recipe)
+	document, err := toDocument(recipe)
+	if err != nil {
+		return nil, err
+	}
+	document["allergens"], document["diets"] = recipe.Allergens, recipe.Diets
+	return document, nil
+}
+
+// classify is dietary.Classify returning empty slices rather than nil, so
+// a recipe with no allergens or diets is stored with empty arrays and is
+// not mistaken by classifyRecipes for one never classified.
+func classify(ingredients []persistence.Ingredient) ([]string, []string) {
+	allergens, diets := dietary.Classify(ingredients)
+	if allergens == nil {
+		allergens = []string{}
+	}
+	if diets == nil {
+		diets = []string{}
+	}
+	return allergens, diets
+}
+
+func (db *DBHandler) AddRecipe(recipe *persistence.Recipe) error {
+	document, err := newRecipeDocument(recipe)
+	if err != nil {
+		return err
+	}
+	_, err = db.recipeCollection.InsertOne(db.context, document)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (db *DBHandler) UpdateRecipe(id string, recipe persistence.Recipe) error {
+// AddRecipes inserts recipes in one batch.
+func (db *DBHandler) AddRecipes(recipes []*persistence.Recipe) error {
+	documents := make([]interface{}, len(recipes))
+	for i, recipe := range recipes {
+		document, err := newRecipeDocument(recipe)
+		if err != nil {
+			return err
+		}
+		documents[i] = document
+	}
+	_, err := db.recipeCollection.InsertMany(db.context, documents)
+	return err
+}
+
+// UpdateRecipe reports db_errors.ErrorNotFound when no recipe has id,
+// including when id is malformed.
+func (db *DBHandler) UpdateRecipe(id string, recipe persistence.Recipe, version int64) error {
 	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return db_errors.ErrorNotFound
+	}
+
+	// version, aggregates and images are owned by the database; version is
+	// bumped on every update
+	recipe.Version = 0
+	recipe.RatingAverage, recipe.RatingCount, recipe.RatingSum = 0, 0, 0
+	recipe.FavoriteCount = 0
+	recipe.Images = nil
+	// classification follows the ingredients and is left alone when they
+	// are not part of the update
+	recipe.Allergens, recipe.Diets = nil, nil
+	fields, err := toDocument(&recipe)
 	if err != nil {
 		return err
 	}
+	if recipe.Ingredients != nil {
+		fields["allergens"], fields["diets"] = classify(recipe.Ingredients)
+	}
 
-	update := bson.M{"$set": &recipe}
-	_, err = db.recipeCollection.UpdateByID(db.context, objectId, update)
+	update := bson.M{"$set": fields, "$inc": bson.M{"version": 1}}
+	result, err := db.recipeCollection.UpdateOne(db.context, recipeFilter(objectId, version), update)
 	if err != nil {
 		return err
 	}
+	if result.MatchedCount == 0 && version != persistence.AnyVersion {
+		return db_errors.ErrorVersionMismatch
+	}
+	if result.MatchedCount == 0 {
+		return db_errors.ErrorNotFound
+	}
 	return nil
 }
 
-func (db *DBHandler) DeleteRecipe(id string) error {
+// toDocument marshals value into a document that extra fields can be added to.
+func toDocument(value interface{}) (bson.M, error) {
+	data, err := bson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var document bson.M
+	err = bson.Unmarshal(data, &document)
+	return document, err
+}
+
+// DeleteRecipe returns the images the recipe had so their blobs can be
+// deleted. It reports db_errors.ErrorNotFound for a malformed id. Deleting
+// a well formed id that matches no recipe succeeds, so deletes can be retried.
+func (db *DBHandler) DeleteRecipe(id string, version int64) ([]persistence.RecipeImage, error) {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, db_errors.ErrorNotFound
+	}
+
+	var recipe persistence.Recipe
+	err = db.recipeCollection.FindOneAndDelete(db.context, recipeFilter(objectId, version)).Decode(&recipe)
+	if err == mongo.ErrNoDocuments && version != persistence.AnyVersion {
+		return nil, db_errors.ErrorVersionMismatch
+	} else if err == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// drop the recipe from every collection it was part of, along with its
+	// reviews and favorites. Cooking logs are kept as history.
+	update := bson.M{"$pull": bson.M{"recipeIds": id}}
+	if _, err = db.collectionCollection.UpdateMany(db.context, bson.M{"recipeIds": id}, update); err != nil {
+		return recipe.Images, err
+	}
+	if _, err = db.reviewCollection.DeleteMany(db.context, bson.M{"recipeId": id}); err != nil {
+		return recipe.Images, err
+	}
+	_, err = db.favoriteCollection.DeleteMany(db.context, bson.M{"recipeId": id})
+	return recipe.Images, err
+}
+
+func (db *DBHandler) AddRecipeImage(id string, image persistence.RecipeImage) error {
 	objectId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
 
-	documentFilter := bson.M{"_id": objectId}
-	_, err = db.recipeCollection.DeleteOne(db.context, documentFilter)
+	update := bson.M{"$push": bson.M{"images": image}}
+	result, err := db.recipeCollection.UpdateByID(db.context, objectId, update)
 	if err != nil {
 		return err
 	}
+	if result.MatchedCount == 0 {
+		return db_errors.ErrorNotFound
+	}
 	return nil
 }
+
+// RemoveRecipeImage detaches an image from a recipe and returns it so its
+// blobs can be deleted.
+func (db *DBHandler) RemoveRecipeImage(id, imageId string) (persistence.RecipeImage, error) {
+	var image persistence.RecipeImage
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return image, err
+	}
+
+	filter := bson.M{"_id": objectId, "images.id": imageId}
+	update := bson.M{"$pull": bson.M{"images": bson.M{"id": imageId}}}
+	var before persistence.Recipe
+	err = db.recipeCollection.FindOneAndUpdate(db.context, filter, update).Decode(&before)
+	if err == mongo.ErrNoDocuments {
+		return image, db_errors.ErrorNotFound
+	} else if err != nil {
+		return image, err
+	}
+
+	for _, candidate := range before.Images {
+		if candidate.ID == imageId {
+			image = candidate
+		}
+	}
+	return image, nil
+}
@@ -0,0 +1,124 @@
+package mongolayer
+
+import (
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	db_errors "github.com/tolopsy/foodpro/api/persistence/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveReview creates the user's review of a recipe or replaces its rating
+// and text, and adjusts the recipe's rating aggregates by the difference in
+// the same transaction.
+func (db *DBHandler) SaveReview(review *persistence.Review) error {
+	recipeId, err := primitive.ObjectIDFromHex(review.RecipeID)
+	if err != nil {
+		return err
+	}
+
+	save := func(tx *DBHandler) error {
+		return tx.saveReview(recipeId, review)
+	}
+	err = db.transaction(save)
+	// two first reviews by the same user racing each other both try to
+	// insert, and the unique index turns one away; run it again to update
+	// the review that won
+	if mongo.IsDuplicateKeyError(err) {
+		err = db.transaction(save)
+	}
+	return err
+}
+
+func (db *DBHandler) saveReview(recipeId primitive.ObjectID, review *persistence.Review) error {
+	now := time.Now()
+	filter := bson.M{"recipeId": review.RecipeID, "username": review.Username}
+	update := bson.M{
+		"$set":         bson.M{"rating": review.Rating, "text": review.Text, "updatedAt": now},
+		"$setOnInsert": bson.M{"createdAt": now},
+	}
+	findOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+	var previous persistence.Review
+	err := db.reviewCollection.FindOneAndUpdate(db.context, filter, update, findOptions).Decode(&previous)
+	sumDelta, countDelta := review.Rating, 1
+	switch err {
+	case nil:
+		sumDelta, countDelta = review.Rating-previous.Rating, 0
+		review.ID, review.CreatedAt = previous.ID, previous.CreatedAt
+	case mongo.ErrNoDocuments:
+		review.CreatedAt = now
+	default:
+		return err
+	}
+	review.UpdatedAt = now
+
+	return db.adjustRating(recipeId, sumDelta, countDelta)
+}
+
+func (db *DBHandler) FetchReviews(recipeId string, skip, limit int) ([]persistence.Review, int, error) {
+	filter := bson.M{"recipeId": recipeId}
+	total, err := db.reviewCollection.CountDocuments(db.context, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.M{"createdAt": -1}).
+		SetSkip(int64(skip)).
+		SetLimit(int64(limit))
+	cursor, err := db.reviewCollection.Find(db.context, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	reviews := make([]persistence.Review, 0)
+	if err = cursor.All(db.context, &reviews); err != nil {
+		return nil, 0, err
+	}
+	return reviews, int(total), nil
+}
+
+// DeleteReview removes the user's review and takes its rating out of the
+// recipe's aggregates in the same transaction.
+func (db *DBHandler) DeleteReview(recipeId, username string) error {
+	objectId, err := primitive.ObjectIDFromHex(recipeId)
+	if err != nil {
+		return err
+	}
+
+	return db.transaction(func(tx *DBHandler) error {
+		var deleted persistence.Review
+		filter := bson.M{"recipeId": recipeId, "username": username}
+		err := tx.reviewCollection.FindOneAndDelete(tx.context, filter).Decode(&deleted)
+		if err == mongo.ErrNoDocuments {
+			return db_errors.ErrorNotFound
+		} else if err != nil {
+			return err
+		}
+		return tx.adjustRating(objectId, -deleted.Rating, -1)
+	})
+}
+
+// adjustRating applies a change to a recipe's rating sum and count and
+// recomputes the average in the same update, so readers never see the
+// aggregates out of step with each other.
+func (db *DBHandler) adjustRating(recipeId primitive.ObjectID, sumDelta, countDelta int) error {
+	sum := bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$ratingSum", 0}}, sumDelta}}
+	count := bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$ratingCount", 0}}, countDelta}}
+	average := bson.M{"$cond": bson.A{
+		bson.M{"$gt": bson.A{"$ratingCount", 0}},
+		bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$ratingSum", "$ratingCount"}}, 2}},
+		0,
+	}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"ratingSum": sum, "ratingCount": count}}},
+		{{Key: "$set", Value: bson.M{"ratingAverage": average}}},
+	}
+	_, err := db.recipeCollection.UpdateByID(db.context, recipeId, pipeline)
+	return err
+}
@@ -0,0 +1,84 @@
+package mongolayer
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	db_errors "github.com/tolopsy/foodpro/api/persistence/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (db *DBHandler) AddShoppingList(list *persistence.ShoppingList) error {
+	list.ID = primitive.NewObjectID()
+	list.CreatedAt = time.Now()
+	_, err := db.shoppingListCollection.InsertOne(db.context, list)
+	return err
+}
+
+func (db *DBHandler) FetchShoppingLists(owner string) ([]persistence.ShoppingList, error) {
+	findOptions := options.Find().SetSort(bson.M{"createdAt": -1})
+	cursor, err := db.shoppingListCollection.Find(db.context, bson.M{"owner": owner}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make([]persistence.ShoppingList, 0)
+	if err = cursor.All(db.context, &lists); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+func (db *DBHandler) GetShoppingList(owner, id string) (persistence.ShoppingList, error) {
+	var list persistence.ShoppingList
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return list, err
+	}
+
+	result := db.shoppingListCollection.FindOne(db.context, bson.M{"_id": objectId, "owner": owner})
+	if err = result.Decode(&list); err == mongo.ErrNoDocuments {
+		return list, db_errors.ErrorNotFound
+	} else if err != nil {
+		return list, err
+	}
+	return list, nil
+}
+
+func (db *DBHandler) CheckShoppingItem(owner, id string, index int, checked bool) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": objectId, "owner": owner}
+	update := bson.M{"$set": bson.M{"items." + strconv.Itoa(index) + ".checked": checked}}
+	result, err := db.shoppingListCollection.UpdateOne(db.context, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db_errors.ErrorNotFound
+	}
+	return nil
+}
+
+func (db *DBHandler) DeleteShoppingList(owner, id string) error {
+	objectId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.shoppingListCollection.DeleteOne(db.context, bson.M{"_id": objectId, "owner": owner})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return db_errors.ErrorNotFound
+	}
+	return nil
+}
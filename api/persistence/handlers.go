@@ -1,19 +1,115 @@
 package persistence
 
+import (
+	"context"
+	"io"
+)
+
 type DatabaseHandler interface {
 	FetchAllRecipes() ([]Recipe, error)
+	EachRecipe(func(Recipe) error) error
+	EachMatchingRecipe(RecipeFilter, func(Recipe) error) error
 	GetRecipe(string) (Recipe, error)
+	FetchRecipesByIDs([]string) ([]Recipe, error)
 	FindRecipesByTag(string) ([]Recipe, error)
+	SearchRecipes(RecipeFilter) ([]Recipe, error)
 	AddRecipe(*Recipe) error
-	UpdateRecipe(string, Recipe) error
-	DeleteRecipe(string) error
+	AddRecipes([]*Recipe) error
+	UpdateRecipe(string, Recipe, int64) error
+	// DeleteRecipe returns the images the recipe had, whose blobs are left
+	// for the caller to delete. They are returned along with any error met
+	// once the recipe itself is gone.
+	DeleteRecipe(string, int64) ([]RecipeImage, error)
+	AddRecipeImage(string, RecipeImage) error
+	RemoveRecipeImage(string, string) (RecipeImage, error)
 	VerifyUser(User) bool
+	// WithContext returns a handler sharing this one's connection whose
+	// calls run under the given context, carrying its cancellation and
+	// trace.
+	WithContext(context.Context) DatabaseHandler
+	// Ping checks the database answers within the context's deadline.
+	Ping(context.Context) error
+	// ServerVersion reports the version of the database server.
+	ServerVersion(context.Context) (string, error)
+	// Close releases the connection. The handler cannot be used afterwards.
+	Close() error
+}
+
+// ShoppingListHandler stores shopping lists. Every lookup takes the owning
+// username first, followed by the list id.
+type ShoppingListHandler interface {
+	AddShoppingList(*ShoppingList) error
+	FetchShoppingLists(string) ([]ShoppingList, error)
+	GetShoppingList(string, string) (ShoppingList, error)
+	CheckShoppingItem(string, string, int, bool) error
+	DeleteShoppingList(string, string) error
+}
+
+// MealPlanHandler stores meal plans, scoped to their owner in the same way
+// as ShoppingListHandler.
+type MealPlanHandler interface {
+	AddMealPlan(*MealPlan) error
+	FetchMealPlans(string) ([]MealPlan, error)
+	GetMealPlan(string, string) (MealPlan, error)
+	UpdateMealPlan(string, string, MealPlan) error
+	DeleteMealPlan(string, string) error
+}
+
+// CollectionHandler stores recipe collections. Lookups by id are not
+// scoped, since shared and public collections are read by other users;
+// changes take the owner first and only apply to the owner's collections.
+type CollectionHandler interface {
+	AddCollection(*Collection) error
+	FetchCollections(string) ([]Collection, error)
+	GetCollection(string) (Collection, error)
+	UpdateCollection(string, string, Collection) error
+	AddCollectionRecipe(string, string, string, int) error
+	RemoveCollectionRecipe(string, string, string) error
+	ReorderCollection(string, string, []string) error
+	DeleteCollection(string, string) error
+}
+
+// ReviewHandler stores reviews and keeps the rating aggregates of their
+// recipes in step with them.
+type ReviewHandler interface {
+	SaveReview(*Review) error
+	// FetchReviews returns a page of a recipe's reviews, newest first, along
+	// with the total number of reviews
+	FetchReviews(string, int, int) ([]Review, int, error)
+	DeleteReview(string, string) error
+}
+
+// ActivityHandler stores what users do with recipes: favorites, which keep
+// the recipe's favorite count in step, and a log of cooked recipes. Every
+// method takes the username first.
+type ActivityHandler interface {
+	AddFavorite(string, string) error
+	RemoveFavorite(string, string) error
+	FetchFavorites(string) ([]Favorite, error)
+	AddCookingLog(*CookingLog) error
+	// FetchCookingHistory returns a page of the user's log, most recent
+	// first, along with the total number of entries
+	FetchCookingHistory(string, int, int) ([]CookingLog, int, error)
 }
 
 type CacheHandler interface {
 	SetRecipes([]Recipe) error
 	GetRecipes() ([]Recipe, error)
 	ClearRecipes() error
+	// WithContext is the cache's counterpart of DatabaseHandler.WithContext.
+	WithContext(context.Context) CacheHandler
+	Ping(context.Context) error
+	ServerVersion(context.Context) (string, error)
+	Close() error
 }
 
 type UserVerifier func(User) bool
+
+// BlobStore keeps binary objects such as recipe images. URL returns where
+// clients can fetch a stored blob from.
+type BlobStore interface {
+	Put(string, io.Reader, string) error
+	Get(string) (io.ReadCloser, string, error)
+	Delete(string) error
+	URL(string) string
+}
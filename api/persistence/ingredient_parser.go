@@ -0,0 +1,225 @@
+package persistence
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// unitAliases maps the spellings found in free-text recipes to the
+// canonical unit names stored on Ingredient.Unit.
+var unitAliases = map[string]string{
+	"tsp": "tsp", "tsps": "tsp", "teaspoon": "tsp", "teaspoons": "tsp", "t": "tsp",
+	"tbsp": "tbsp", "tbsps": "tbsp", "tbs": "tbsp", "tablespoon": "tbsp", "tablespoons": "tbsp", "T": "tbsp",
+	"cup": "cup", "cups": "cup", "c": "cup",
+	"floz": "fl oz", "fl oz": "fl oz", "fluid ounce": "fl oz", "fluid ounces": "fl oz",
+	"pint": "pint", "pints": "pint", "pt": "pint",
+	"quart": "quart", "quarts": "quart", "qt": "quart",
+	"gallon": "gallon", "gallons": "gallon", "gal": "gallon",
+	"ml": "ml", "milliliter": "ml", "milliliters": "ml", "millilitre": "ml", "millilitres": "ml",
+	"l": "l", "liter": "l", "liters": "l", "litre": "l", "litres": "l",
+	"g": "g", "gram": "g", "grams": "g", "gr": "g",
+	"kg": "kg", "kilogram": "kg", "kilograms": "kg",
+	"oz": "oz", "ounce": "oz", "ounces": "oz",
+	"lb": "lb", "lbs": "lb", "pound": "lb", "pounds": "lb",
+	"pinch": "pinch", "pinches": "pinch",
+	"dash": "dash", "dashes": "dash",
+	"clove": "clove", "cloves": "clove",
+	"can": "can", "cans": "can",
+	"slice": "slice", "slices": "slice",
+	"stick": "stick", "sticks": "stick",
+	"bunch": "bunch", "bunches": "bunch",
+	"handful": "handful", "handfuls": "handful",
+	"package": "package", "packages": "package", "pkg": "package",
+}
+
+// preparationWords are leading adjectives that describe how an item is
+// prepared rather than what it is, e.g. "chopped" in "chopped onions".
+var preparationWords = map[string]bool{
+	"chopped": true, "diced": true, "minced": true, "sliced": true, "grated": true,
+	"shredded": true, "crushed": true, "melted": true, "softened": true, "beaten": true,
+	"peeled": true, "cubed": true, "mashed": true, "ground": true, "toasted": true,
+	"finely": true, "roughly": true, "coarsely": true, "thinly": true, "freshly": true,
+}
+
+var unicodeFractions = map[rune]float64{
+	'¼': 0.25, '½': 0.5, '¾': 0.75,
+	'⅓': 1.0 / 3, '⅔': 2.0 / 3,
+	'⅛': 0.125, '⅜': 0.375, '⅝': 0.625, '⅞': 0.875,
+}
+
+// ParseIngredient turns a free-text ingredient line such as
+// "2 1/2 cups chopped onions" into its structured form. Lines it cannot
+// make sense of are kept whole as the item.
+func ParseIngredient(line string) Ingredient {
+	var ingredient Ingredient
+	line = strings.TrimSpace(line)
+
+	for _, marker := range []string{"(optional)", ", optional", "optional:"} {
+		if index := strings.Index(lowerASCII(line), marker); index >= 0 {
+			ingredient.Optional = true
+			line = strings.TrimSpace(line[:index] + line[index+len(marker):])
+		}
+	}
+
+	var notes []string
+	if index := strings.Index(line, ","); index >= 0 {
+		notes = append(notes, strings.TrimSpace(line[index+1:]))
+		line = line[:index]
+	}
+	if open := strings.Index(line, "("); open >= 0 {
+		if end := strings.Index(line[open:], ")"); end >= 0 {
+			notes = append(notes, strings.TrimSpace(line[open+1:open+end]))
+			line = line[:open] + line[open+end+1:]
+		}
+	}
+
+	words := splitGluedUnit(strings.Fields(splitUnicodeFractions(line)))
+	ingredient.Quantity, words = parseQuantity(words)
+	ingredient.Unit, words = parseUnit(words)
+	if len(words) > 0 && strings.EqualFold(words[0], "of") {
+		words = words[1:]
+	}
+
+	var preparation []string
+	for len(words) > 1 && preparationWords[strings.ToLower(words[0])] {
+		preparation = append(preparation, words[0])
+		words = words[1:]
+	}
+	if len(preparation) > 0 {
+		notes = append([]string{strings.Join(preparation, " ")}, notes...)
+	}
+
+	ingredient.Item = strings.Join(words, " ")
+	ingredient.Note = strings.Join(notes, ", ")
+	return ingredient
+}
+
+// splitUnicodeFractions separates glyphs like "2½" into "2 ½" so they can
+// be read as mixed numbers.
+func splitUnicodeFractions(line string) string {
+	var builder strings.Builder
+	for _, r := range line {
+		if _, ok := unicodeFractions[r]; ok {
+			builder.WriteRune(' ')
+			builder.WriteRune(r)
+			builder.WriteRune(' ')
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// parseQuantity consumes a leading number, fraction or mixed number such as
+// "2 1/2". For ranges like "2-3" the lower bound is used.
+func parseQuantity(words []string) (float64, []string) {
+	if len(words) == 0 {
+		return 0, words
+	}
+	first := words[0]
+	if index := strings.Index(first, "-"); index > 0 {
+		first = first[:index]
+	}
+	total, ok := parseNumber(first)
+	if !ok {
+		return 0, words
+	}
+	if total == math.Trunc(total) && len(words) > 1 {
+		if fraction, ok := parseNumber(words[1]); ok && fraction < 1 {
+			return total + fraction, words[2:]
+		}
+	}
+	return total, words[1:]
+}
+
+func parseNumber(word string) (float64, bool) {
+	if runes := []rune(word); len(runes) == 1 {
+		if value, ok := unicodeFractions[runes[0]]; ok {
+			return value, true
+		}
+	}
+	if slash := strings.Index(word, "/"); slash > 0 {
+		numerator, err := strconv.ParseFloat(word[:slash], 64)
+		if err != nil {
+			return 0, false
+		}
+		denominator, err := strconv.ParseFloat(word[slash+1:], 64)
+		if err != nil || denominator == 0 {
+			return 0, false
+		}
+		return numerator / denominator, true
+	}
+	if word == "" || !unicode.IsDigit(rune(word[0])) {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(word, 64)
+	return value, err == nil
+}
+
+var gluedUnitPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)([A-Za-z]+\.?)$`)
+
+// splitGluedUnit separates a quantity written against its unit, as in
+// "200g", into two words.
+func splitGluedUnit(words []string) []string {
+	if len(words) == 0 {
+		return words
+	}
+	match := gluedUnitPattern.FindStringSubmatch(words[0])
+	if match == nil {
+		return words
+	}
+	if _, ok := lookupUnit(match[2]); !ok {
+		return words
+	}
+	return append([]string{match[1], match[2]}, words[1:]...)
+}
+
+// parseUnit consumes a unit name, including two word units like "fl oz".
+func parseUnit(words []string) (string, []string) {
+	if len(words) == 0 {
+		return "", words
+	}
+	if len(words) > 1 {
+		if unit, ok := lookupUnit(words[0] + " " + words[1]); ok {
+			return unit, words[2:]
+		}
+	}
+	if unit, ok := lookupUnit(words[0]); ok && len(words) > 1 {
+		return unit, words[1:]
+	}
+	return "", words
+}
+
+func lookupUnit(word string) (string, bool) {
+	word = strings.TrimSuffix(word, ".")
+	if unit, ok := unitAliases[word]; ok {
+		return unit, true
+	}
+	unit, ok := unitAliases[strings.ToLower(word)]
+	return unit, ok
+}
+
+// CanonicalUnit returns the canonical spelling of a unit, or the input
+// unchanged if the unit is unknown.
+func CanonicalUnit(unit string) string {
+	if canonical, ok := lookupUnit(unit); ok {
+		return canonical
+	}
+	return unit
+}
+
+// lowerASCII lowercases only ASCII letters, so byte offsets into the result
+// are offsets into s. strings.ToLower can change the length of other
+// letters, such as İ.
+func lowerASCII(s string) string {
+	lower := []byte(s)
+	for i, c := range lower {
+		if 'A' <= c && c <= 'Z' {
+			lower[i] = c + 'a' - 'A'
+		}
+	}
+	return string(lower)
+}
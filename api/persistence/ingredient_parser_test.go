@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseIngredient(t *testing.T) {
+	tests := []struct {
+		line string
+		want Ingredient
+	}{
+		{"2 1/2 cups chopped onions", Ingredient{Quantity: 2.5, Unit: "cup", Item: "onions", Note: "chopped"}},
+		{"1½ tsp salt", Ingredient{Quantity: 1.5, Unit: "tsp", Item: "salt"}},
+		{"200g flour", Ingredient{Quantity: 200, Unit: "g", Item: "flour"}},
+		{"2-3 cloves garlic, minced", Ingredient{Quantity: 2, Unit: "clove", Item: "garlic", Note: "minced"}},
+		{"1 cup of milk", Ingredient{Quantity: 1, Unit: "cup", Item: "milk"}},
+		{"8 fl oz cream", Ingredient{Quantity: 8, Unit: "fl oz", Item: "cream"}},
+		{"3 eggs", Ingredient{Quantity: 3, Item: "eggs"}},
+		{"1 tsp ground cumin", Ingredient{Quantity: 1, Unit: "tsp", Item: "cumin", Note: "ground"}},
+		{"1 lb ground beef (lean)", Ingredient{Quantity: 1, Unit: "lb", Item: "beef", Note: "ground, lean"}},
+		{"parsley (optional)", Ingredient{Item: "parsley", Optional: true}},
+		{"Chives, Optional", Ingredient{Item: "Chives", Optional: true}},
+		{"ȺȺȺ salt (optional)", Ingredient{Item: "ȺȺȺ salt", Optional: true}},
+		{"İİİİ flour, optional", Ingredient{Item: "İİİİ flour", Optional: true}},
+		{"salt and pepper to taste", Ingredient{Item: "salt and pepper to taste"}},
+	}
+	for _, test := range tests {
+		if got := ParseIngredient(test.line); got != test.want {
+			t.Errorf("ParseIngredient(%q) = %+v, want %+v", test.line, got, test.want)
+		}
+	}
+}
+
+// A lone preparation word is the item itself, not a note about it.
+func TestParseIngredientKeepsLastWordAsItem(t *testing.T) {
+	if got := ParseIngredient("1 cup chopped"); got.Item != "chopped" || got.Note != "" {
+		t.Errorf("ParseIngredient(%q) = %+v, want item chopped and no note", "1 cup chopped", got)
+	}
+}
+
+func TestIngredientUnmarshalJSON(t *testing.T) {
+	var ingredients []Ingredient
+	data := `["2 tbsp butter, softened", {"quantity": 1, "unit": "cup", "item": "sugar"}]`
+	if err := json.Unmarshal([]byte(data), &ingredients); err != nil {
+		t.Fatal(err)
+	}
+	want := []Ingredient{
+		{Quantity: 2, Unit: "tbsp", Item: "butter", Note: "softened"},
+		{Quantity: 1, Unit: "cup", Item: "sugar"},
+	}
+	for i := range want {
+		if ingredients[i] != want[i] {
+			t.Errorf("ingredient %d = %+v, want %+v", i, ingredients[i], want[i])
+		}
+	}
+}
+
+func TestIngredientString(t *testing.T) {
+	ingredient := Ingredient{Quantity: 2, Unit: "cup", Item: "walnuts", Note: "toasted", Optional: true}
+	if got, want := ingredient.String(), "2 cup walnuts, toasted (optional)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCanonicalUnit(t *testing.T) {
+	for unit, want := range map[string]string{"Tablespoons": "tbsp", "T": "tbsp", "t": "tsp", "lbs.": "lb", "sprig": "sprig"} {
+		if got := CanonicalUnit(unit); got != want {
+			t.Errorf("CanonicalUnit(%q) = %q, want %q", unit, got, want)
+		}
+	}
+}
+
+func TestNormalizeItem(t *testing.T) {
+	for item, want := range map[string]string{"Onions": "onion", "tomatoes": "tomato", "Swiss  Chard": "swiss chard", "glass": "glass", "peas": "pea"} {
+		if got := NormalizeItem(item); got != want {
+			t.Errorf("NormalizeItem(%q) = %q, want %q", item, got, want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/units"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+type Ingredient struct {
+	Quantity float64 `json:"quantity,omitempty" bson:"quantity,omitempty"`
+	Unit     string  `json:"unit,omitempty" bson:"unit,omitempty"`
+	Item     string  `json:"item" bson:"item"`
+	Note     string  `json:"note,omitempty" bson:"note,omitempty"`
+	Optional bool    `json:"optional,omitempty" bson:"optional,omitempty"`
+}
+
+// ingredientFields exists so the custom unmarshalers below can decode the
+// structured form without recursing into themselves.
+type ingredientFields Ingredient
+
+// UnmarshalJSON accepts either the structured object or a free-text line
+// such as "2 1/2 cups chopped onions".
+func (ingredient *Ingredient) UnmarshalJSON(data []byte) error {
+	var line string
+	if err := json.Unmarshal(data, &line); err == nil {
+		*ingredient = ParseIngredient(line)
+		return nil
+	}
+	return json.Unmarshal(data, (*ingredientFields)(ingredient))
+}
+
+// UnmarshalBSONValue lets recipes stored with string ingredients be read
+// before they have been migrated.
+func (ingredient *Ingredient) UnmarshalBSONValue(valueType bsontype.Type, data []byte) error {
+	switch valueType {
+	case bsontype.String:
+		line, _, ok := bsoncore.ReadString(data)
+		if !ok {
+			return errors.New("invalid ingredient string")
+		}
+		*ingredient = ParseIngredient(line)
+		return nil
+	case bsontype.EmbeddedDocument:
+		return bson.Unmarshal(data, (*ingredientFields)(ingredient))
+	default:
+		return errors.New("cannot decode ingredient from bson type " + valueType.String())
+	}
+}
+
+// String renders the ingredient back into a single human readable line.
+func (ingredient Ingredient) String() string {
+	var parts []string
+	if ingredient.Quantity > 0 {
+		parts = append(parts, units.Format(ingredient.Quantity, ingredient.Unit))
+	}
+	if ingredient.Unit != "" {
+		parts = append(parts, ingredient.Unit)
+	}
+	parts = append(parts, ingredient.Item)
+
+	line := strings.Join(parts, " ")
+	if ingredient.Note != "" {
+		line += ", " + ingredient.Note
+	}
+	if ingredient.Optional {
+		line += " (optional)"
+	}
+	return line
+}
+
+// NormalizeItem lowercases an ingredient item and drops simple plural
+// endings, so "Onions" and "onion" compare equal.
+func NormalizeItem(item string) string {
+	words := strings.Fields(strings.ToLower(item))
+	for i, word := range words {
+		switch {
+		case strings.HasSuffix(word, "oes"):
+			words[i] = strings.TrimSuffix(word, "es")
+		case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && len(word) > 3:
+			words[i] = strings.TrimSuffix(word, "s")
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,29 @@
+package persistence
+
+import "time"
+
+// Meal slots a recipe can be planned for.
+const (
+	Breakfast = "breakfast"
+	Lunch     = "lunch"
+	Dinner    = "dinner"
+	Snack     = "snack"
+)
+
+type MealPlan struct {
+	ID        interface{}     `json:"id,omitempty" bson:"_id,omitempty"`
+	Owner     string          `json:"owner" bson:"owner"`
+	Name      string          `json:"name" bson:"name"`
+	Entries   []MealPlanEntry `json:"entries" bson:"entries"`
+	CreatedAt time.Time       `json:"createdAt" bson:"createdAt"`
+}
+
+// MealPlanEntry assigns a recipe to a meal slot on a day. Date is formatted
+// as YYYY-MM-DD.
+type MealPlanEntry struct {
+	Date       string `json:"date" bson:"date" binding:"required"`
+	Slot       string `json:"slot" bson:"slot" binding:"required,oneof=breakfast lunch dinner snack"`
+	RecipeID   string `json:"recipeId" bson:"recipeId" binding:"required"`
+	RecipeName string `json:"recipeName,omitempty" bson:"recipeName,omitempty"`
+	Servings   int    `json:"servings,omitempty" bson:"servings,omitempty"`
+}
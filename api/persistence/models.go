@@ -1,16 +1,78 @@
 package persistence
 
 import (
+	"sort"
 	"time"
 )
 
 type Recipe struct {
-	ID           interface{} `json:"id,omitempty" bson:"_id,omitempty"`
-	Name         string      `json:"name,omitempty" bson:"name,omitempty"`
-	Tags         []string    `json:"tags,omitempty" bson:"tags,omitempty"`
-	Ingredients  []string    `json:"ingredients,omitempty" bson:"ingredients,omitempty"`
-	Instructions []string    `json:"instructions,omitempty" bson:"instructions,omitempty"`
-	PublishedAt  time.Time   `json:"publishedAt,omitempty" bson:"publishedAt,omitempty"`
+	ID           interface{}   `json:"id,omitempty" bson:"_id,omitempty"`
+	Name         string        `json:"name,omitempty" bson:"name,omitempty"`
+	Tags         []string      `json:"tags,omitempty" bson:"tags,omitempty"`
+	Ingredients  []Ingredient  `json:"ingredients,omitempty" bson:"ingredients,omitempty"`
+	Instructions []string      `json:"instructions,omitempty" bson:"instructions,omitempty"`
+	Servings     int           `json:"servings,omitempty" bson:"servings,omitempty"`
+	Allergens    []string      `json:"allergens,omitempty" bson:"allergens,omitempty"`
+	Diets        []string      `json:"diets,omitempty" bson:"diets,omitempty"`
+	Images       []RecipeImage `json:"images,omitempty" bson:"images,omitempty"`
+	PublishedAt  time.Time     `json:"publishedAt,omitempty" bson:"publishedAt,omitempty"`
+	Version      int64         `json:"version,omitempty" bson:"version,omitempty"`
+	// aggregates maintained by the database as reviews and favorites change
+	RatingAverage float64 `json:"ratingAverage,omitempty" bson:"ratingAverage,omitempty"`
+	RatingCount   int     `json:"ratingCount,omitempty" bson:"ratingCount,omitempty"`
+	RatingSum     int     `json:"-" bson:"ratingSum,omitempty"`
+	FavoriteCount int     `json:"favoriteCount,omitempty" bson:"favoriteCount,omitempty"`
+}
+
+// IDString renders the recipe's id the way it appears in JSON, or returns
+// an empty string for a recipe that has not been stored.
+func (recipe Recipe) IDString() string {
+	if hex, ok := recipe.ID.(interface{ Hex() string }); ok {
+		return hex.Hex()
+	}
+	if id, ok := recipe.ID.(string); ok {
+		return id
+	}
+	return ""
+}
+
+// RecipeImage is an uploaded picture of a recipe with its generated
+// renditions. Keys locate the stored blobs and are not exposed.
+type RecipeImage struct {
+	ID           string   `json:"id" bson:"id"`
+	URL          string   `json:"url" bson:"url"`
+	MediumURL    string   `json:"mediumUrl" bson:"mediumUrl"`
+	ThumbnailURL string   `json:"thumbnailUrl" bson:"thumbnailUrl"`
+	ContentType  string   `json:"contentType" bson:"contentType"`
+	Width        int      `json:"width" bson:"width"`
+	Height       int      `json:"height" bson:"height"`
+	Keys         []string `json:"-" bson:"keys"`
+}
+
+// SortByRating orders recipes from best to worst rated. Ties go to the
+// recipe with more ratings, and unrated recipes come last.
+func SortByRating(recipes []Recipe) {
+	sort.SliceStable(recipes, func(i, j int) bool {
+		if recipes[i].RatingAverage != recipes[j].RatingAverage {
+			return recipes[i].RatingAverage > recipes[j].RatingAverage
+		}
+		return recipes[i].RatingCount > recipes[j].RatingCount
+	})
+}
+
+// AnyVersion can be passed wherever an expected recipe version is required
+// to skip the optimistic concurrency check.
+const AnyVersion int64 = -1
+
+// RecipeFilter narrows a recipe search. Empty fields do not constrain the
+// results.
+type RecipeFilter struct {
+	Tag              string
+	Diets            []string
+	ExcludeAllergens []string
+	// ByRating orders the results as SortByRating does, rather than in
+	// insertion order.
+	ByRating bool
 }
 
 type User struct {
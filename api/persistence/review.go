@@ -0,0 +1,15 @@
+package persistence
+
+import "time"
+
+// Review is a user's rating of a recipe, with optional text. Each user has
+// at most one review per recipe.
+type Review struct {
+	ID        interface{} `json:"id,omitempty" bson:"_id,omitempty"`
+	RecipeID  string      `json:"recipeId" bson:"recipeId"`
+	Username  string      `json:"username" bson:"username"`
+	Rating    int         `json:"rating" bson:"rating" binding:"required,min=1,max=5"`
+	Text      string      `json:"text,omitempty" bson:"text,omitempty"`
+	CreatedAt time.Time   `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt" bson:"updatedAt"`
+}
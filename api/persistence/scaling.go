@@ -0,0 +1,41 @@
+package persistence
+
+import (
+	"errors"
+
+	"github.com/tolopsy/foodpro/api/units"
+)
+
+var ErrorNoServings = errors.New("recipe does not specify how many servings it makes")
+
+// Scale returns a copy of the recipe sized for servings, with quantities
+// converted into system when it is set and rounded for display. A servings
+// value of zero keeps the recipe's own yield.
+func (recipe Recipe) Scale(servings int, system units.System) (Recipe, error) {
+	factor := 1.0
+	if servings > 0 {
+		if recipe.Servings <= 0 {
+			return recipe, ErrorNoServings
+		}
+		factor = float64(servings) / float64(recipe.Servings)
+		recipe.Servings = servings
+	}
+
+	ingredients := make([]Ingredient, len(recipe.Ingredients))
+	for i, ingredient := range recipe.Ingredients {
+		quantity, unit := ingredient.Quantity*factor, ingredient.Unit
+		if system != "" {
+			var err error
+			if quantity, unit, err = units.ToSystem(quantity, unit, system); err != nil {
+				return recipe, err
+			}
+		}
+		if ingredient.Quantity > 0 {
+			ingredient.Quantity = units.Round(quantity, unit)
+		}
+		ingredient.Unit = unit
+		ingredients[i] = ingredient
+	}
+	recipe.Ingredients = ingredients
+	return recipe, nil
+}
@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/tolopsy/foodpro/api/units"
+)
+
+func TestScale(t *testing.T) {
+	recipe := Recipe{Servings: 4, Ingredients: []Ingredient{
+		{Quantity: 2, Unit: "cup", Item: "flour"},
+		{Quantity: 3, Item: "eggs"},
+		{Item: "salt"},
+	}}
+	scaled, err := recipe.Scale(6, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Ingredient{
+		{Quantity: 3, Unit: "cup", Item: "flour"},
+		{Quantity: 4.5, Item: "eggs"},
+		{Item: "salt"},
+	}
+	if scaled.Servings != 6 {
+		t.Errorf("Servings = %d, want 6", scaled.Servings)
+	}
+	for i := range want {
+		if scaled.Ingredients[i] != want[i] {
+			t.Errorf("ingredient %d = %+v, want %+v", i, scaled.Ingredients[i], want[i])
+		}
+	}
+	if recipe.Ingredients[0].Quantity != 2 {
+		t.Error("Scale changed the original recipe's ingredients")
+	}
+}
+
+func TestScaleToSystem(t *testing.T) {
+	recipe := Recipe{Servings: 2, Ingredients: []Ingredient{{Quantity: 1, Unit: "cup", Item: "milk"}}}
+	scaled, err := recipe.Scale(1, units.Metric)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := scaled.Ingredients[0], (Ingredient{Quantity: 118, Unit: "ml", Item: "milk"}); got != want {
+		t.Errorf("ingredient = %+v, want %+v", got, want)
+	}
+}
+
+func TestScaleWithoutServings(t *testing.T) {
+	if _, err := (Recipe{}).Scale(2, ""); err != ErrorNoServings {
+		t.Errorf("error = %v, want %v", err, ErrorNoServings)
+	}
+}
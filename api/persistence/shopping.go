@@ -0,0 +1,28 @@
+package persistence
+
+import "time"
+
+type ShoppingList struct {
+	ID        interface{}          `json:"id,omitempty" bson:"_id,omitempty"`
+	Owner     string               `json:"owner" bson:"owner"`
+	Name      string               `json:"name,omitempty" bson:"name,omitempty"`
+	Recipes   []ShoppingListRecipe `json:"recipes" bson:"recipes"`
+	Items     []ShoppingItem       `json:"items" bson:"items"`
+	CreatedAt time.Time            `json:"createdAt" bson:"createdAt"`
+}
+
+// ShoppingListRecipe records which recipe, at how many servings, a list
+// was built from.
+type ShoppingListRecipe struct {
+	RecipeID string `json:"id" bson:"recipeId" binding:"required"`
+	Servings int    `json:"servings,omitempty" bson:"servings,omitempty"`
+}
+
+type ShoppingItem struct {
+	Item     string  `json:"item" bson:"item"`
+	Quantity float64 `json:"quantity,omitempty" bson:"quantity,omitempty"`
+	Unit     string  `json:"unit,omitempty" bson:"unit,omitempty"`
+	Aisle    string  `json:"aisle" bson:"aisle"`
+	Optional bool    `json:"optional,omitempty" bson:"optional,omitempty"`
+	Checked  bool    `json:"checked" bson:"checked"`
+}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/blob"
+	"github.com/tolopsy/foodpro/api/persistence/blob/localfs"
+)
+
+type BLOB_STORE string
+
+const (
+	LOCAL_FS BLOB_STORE = "local"
+)
+
+func NewBlobStore(storeType, location, baseURL string) (persistence.BlobStore, error) {
+	switch BLOB_STORE(storeType) {
+	case LOCAL_FS:
+		return localfs.NewBlobStore(location, baseURL)
+	default:
+		return nil, blob.ErrorBlobStorePluginDoesNotExist
+	}
+}
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"log/slog"
+
 	"github.com/tolopsy/foodpro/api/persistence"
 	"github.com/tolopsy/foodpro/api/persistence/cache"
 	"github.com/tolopsy/foodpro/api/persistence/cache/redisclient"
@@ -12,10 +14,10 @@ const (
 	REDIS CACHE_SERVER = "redis"
 )
 
-func NewCacheHandler(cacheType, host, password string) (persistence.CacheHandler, error) {
+func NewCacheHandler(cacheType, host, password string, logger *slog.Logger) (persistence.CacheHandler, error) {
 	switch CACHE_SERVER(cacheType) {
 	case REDIS:
-		return redisclient.NewCacheHandler(host, password)
+		return redisclient.NewCacheHandler(host, password, logger)
 	default:
 		return nil, cache.ErrorCacheServerPluginDoesNotExist
 	}
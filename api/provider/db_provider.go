@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"log/slog"
+
 	"github.com/tolopsy/foodpro/api/persistence"
 	"github.com/tolopsy/foodpro/api/persistence/db"
 	"github.com/tolopsy/foodpro/api/persistence/db/mongolayer"
@@ -12,11 +14,80 @@ const (
 	MONGO_DB DBTYPE = "mongodb"
 )
 
-func NewDBHandler(dbType, dbURI, dbName string) (persistence.DatabaseHandler, error) {
+func NewDBHandler(dbType, dbURI, dbName string, logger *slog.Logger) (persistence.DatabaseHandler, error) {
 	switch DBTYPE(dbType) {
 	case MONGO_DB:
-		return mongolayer.NewMongoDBHandler(dbURI, dbName)
+		return mongolayer.NewMongoDBHandler(dbURI, dbName, logger)
+	default:
+		return nil, db.ErrorDBPluginDoesNotExist
+	}
+}
+
+// NewShoppingListHandler returns the shopping list store of a database
+// plugin. It shares the plugin's connection.
+func NewShoppingListHandler(database persistence.DatabaseHandler) (persistence.ShoppingListHandler, error) {
+	switch handler := backend(database).(type) {
+	case *mongolayer.DBHandler:
+		return handler, nil
 	default:
 		return nil, db.ErrorDBPluginDoesNotExist
 	}
 }
+
+// NewMealPlanHandler returns the meal plan store of a database plugin. It
+// shares the plugin's connection.
+func NewMealPlanHandler(database persistence.DatabaseHandler) (persistence.MealPlanHandler, error) {
+	switch handler := backend(database).(type) {
+	case *mongolayer.DBHandler:
+		return handler, nil
+	default:
+		return nil, db.ErrorDBPluginDoesNotExist
+	}
+}
+
+// NewCollectionHandler returns the recipe collection store of a database
+// plugin. It shares the plugin's connection.
+func NewCollectionHandler(database persistence.DatabaseHandler) (persistence.CollectionHandler, error) {
+	switch handler := backend(database).(type) {
+	case *mongolayer.DBHandler:
+		return handler, nil
+	default:
+		return nil, db.ErrorDBPluginDoesNotExist
+	}
+}
+
+// NewReviewHandler returns the review store of a database plugin. It shares
+// the plugin's connection.
+func NewReviewHandler(database persistence.DatabaseHandler) (persistence.ReviewHandler, error) {
+	switch handler := backend(database).(type) {
+	case *mongolayer.DBHandler:
+		return handler, nil
+	default:
+		return nil, db.ErrorDBPluginDoesNotExist
+	}
+}
+
+// NewActivityHandler returns the favorites and cooking log store of a
+// database plugin. It shares the plugin's connection.
+func NewActivityHandler(database persistence.DatabaseHandler) (persistence.ActivityHandler, error) {
+	switch handler := backend(database).(type) {
+	case *mongolayer.DBHandler:
+		return handler, nil
+	default:
+		return nil, db.ErrorDBPluginDoesNotExist
+	}
+}
+
+// backend sees through decorators such as metrics.DatabaseHandler, which
+// implement Unwrap, to the plugin's own handler.
+func backend(database persistence.DatabaseHandler) persistence.DatabaseHandler {
+	for {
+		wrapper, ok := database.(interface {
+			Unwrap() persistence.DatabaseHandler
+		})
+		if !ok {
+			return database
+		}
+		database = wrapper.Unwrap()
+	}
+}
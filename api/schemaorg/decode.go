@@ -0,0 +1,205 @@
+// Package schemaorg converts between persistence.Recipe and the schema.org
+// Recipe vocabulary used in JSON-LD documents and web pages.
+package schemaorg
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// FindRecipes decodes a JSON-LD document and returns every node typed as a
+// Recipe, wherever it sits: at the top level, in an array or in @graph.
+func FindRecipes(data []byte) ([]map[string]interface{}, error) {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	var recipes []map[string]interface{}
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch value := node.(type) {
+		case []interface{}:
+			for _, element := range value {
+				walk(element)
+			}
+		case map[string]interface{}:
+			if hasType(value, "Recipe") {
+				recipes = append(recipes, value)
+				return
+			}
+			walk(value["@graph"])
+			walk(value["mainEntity"])
+		}
+	}
+	walk(document)
+	return recipes, nil
+}
+
+func hasType(node map[string]interface{}, name string) bool {
+	for _, value := range texts(node["@type"]) {
+		if value == name || strings.HasSuffix(value, "/"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToRecipe maps a schema.org Recipe node onto a recipe. Fields it cannot
+// read are reported rather than failing the whole node.
+func ToRecipe(node map[string]interface{}) (persistence.Recipe, []string) {
+	var recipe persistence.Recipe
+	var problems []string
+
+	recipe.Name = clean(first(texts(node["name"])))
+
+	ingredients := texts(node["recipeIngredient"])
+	if len(ingredients) == 0 {
+		// the vocabulary's older name for the same property
+		ingredients = texts(node["ingredients"])
+	}
+	for _, line := range ingredients {
+		if line = clean(line); line != "" {
+			recipe.Ingredients = append(recipe.Ingredients, persistence.ParseIngredient(line))
+		}
+	}
+
+	recipe.Instructions = instructions(node["recipeInstructions"])
+
+	for _, property := range []string{"keywords", "recipeCategory", "recipeCuisine"} {
+		for _, value := range texts(node[property]) {
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.ToLower(clean(tag)); tag != "" && !contains(recipe.Tags, tag) {
+					recipe.Tags = append(recipe.Tags, tag)
+				}
+			}
+		}
+	}
+
+	if yield, ok := node["recipeYield"]; ok {
+		if recipe.Servings, ok = servings(yield); !ok {
+			problems = append(problems, "recipeYield does not state a number of servings")
+		}
+	}
+
+	if published := first(texts(node["datePublished"])); published != "" {
+		if date, err := parseDate(published); err == nil {
+			recipe.PublishedAt = date
+		} else {
+			problems = append(problems, "datePublished is not a valid date")
+		}
+	}
+	return recipe, problems
+}
+
+// instructions flattens plain text, lists of text, HowToStep and
+// HowToSection nodes into a list of steps.
+func instructions(node interface{}) []string {
+	var steps []string
+	switch value := node.(type) {
+	case string:
+		for _, line := range strings.Split(value, "\n") {
+			if line = clean(line); line != "" {
+				steps = append(steps, line)
+			}
+		}
+	case []interface{}:
+		for _, element := range value {
+			steps = append(steps, instructions(element)...)
+		}
+	case map[string]interface{}:
+		if hasType(value, "HowToSection") {
+			return instructions(value["itemListElement"])
+		}
+		text := first(texts(value["text"]))
+		if text == "" {
+			text = first(texts(value["name"]))
+		}
+		if text = clean(text); text != "" {
+			steps = append(steps, text)
+		}
+	}
+	return steps
+}
+
+var leadingNumber = regexp.MustCompile(`\d+`)
+
+// servings reads yields such as 4, "4", "4 servings" or ["4", "4 servings"].
+func servings(node interface{}) (int, bool) {
+	switch value := node.(type) {
+	case float64:
+		return int(value), value > 0
+	case string:
+		if match := leadingNumber.FindString(value); match != "" {
+			count, err := strconv.Atoi(match)
+			return count, err == nil && count > 0
+		}
+	case []interface{}:
+		for _, element := range value {
+			if count, ok := servings(element); ok {
+				return count, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func parseDate(value string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// texts reads a property that may hold a single string, a list of
+// strings, or objects carrying the text in @value or name.
+func texts(node interface{}) []string {
+	switch value := node.(type) {
+	case string:
+		return []string{value}
+	case float64:
+		return []string{strconv.FormatFloat(value, 'f', -1, 64)}
+	case []interface{}:
+		var values []string
+		for _, element := range value {
+			values = append(values, texts(element)...)
+		}
+		return values
+	case map[string]interface{}:
+		if text, ok := value["@value"]; ok {
+			return texts(text)
+		}
+		return texts(value["name"])
+	}
+	return nil
+}
+
+var markup = regexp.MustCompile(`<[^>]*>`)
+
+// clean strips markup and entities some sites leave in their structured data.
+func clean(text string) string {
+	text = html.UnescapeString(markup.ReplaceAllString(text, ""))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
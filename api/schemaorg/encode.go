@@ -0,0 +1,78 @@
+package schemaorg
+
+import (
+	"time"
+
+	"github.com/tolopsy/foodpro/api/dietary"
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// FromRecipe renders a recipe as a schema.org Recipe node ready to be
+// marshalled as JSON-LD. url, when set, is used as the node's @id.
+func FromRecipe(recipe persistence.Recipe, url string) map[string]interface{} {
+	node := map[string]interface{}{
+		"@context": "https://schema.org",
+		"@type":    "Recipe",
+		"name":     recipe.Name,
+	}
+	if url != "" {
+		node["@id"] = url
+		node["url"] = url
+	}
+
+	ingredients := make([]string, len(recipe.Ingredients))
+	for i, ingredient := range recipe.Ingredients {
+		ingredients[i] = ingredient.String()
+	}
+	node["recipeIngredient"] = ingredients
+
+	steps := make([]map[string]interface{}, len(recipe.Instructions))
+	for i, instruction := range recipe.Instructions {
+		steps[i] = map[string]interface{}{"@type": "HowToStep", "position": i + 1, "text": instruction}
+	}
+	node["recipeInstructions"] = steps
+
+	if len(recipe.Tags) > 0 {
+		node["keywords"] = recipe.Tags
+	}
+	if recipe.Servings > 0 {
+		node["recipeYield"] = recipe.Servings
+	}
+	if !recipe.PublishedAt.IsZero() {
+		node["datePublished"] = recipe.PublishedAt.Format(time.RFC3339)
+	}
+	if len(recipe.Images) > 0 {
+		images := make([]string, len(recipe.Images))
+		for i, image := range recipe.Images {
+			images[i] = image.URL
+		}
+		node["image"] = images
+	}
+	if recipe.RatingCount > 0 {
+		node["aggregateRating"] = map[string]interface{}{
+			"@type":       "AggregateRating",
+			"ratingValue": recipe.RatingAverage,
+			"ratingCount": recipe.RatingCount,
+			"bestRating":  5,
+			"worstRating": 1,
+		}
+	}
+	for _, diet := range recipe.Diets {
+		if schemaDiet, ok := suitableForDiet[diet]; ok {
+			node["suitableForDiet"] = append(asList(node["suitableForDiet"]), schemaDiet)
+		}
+	}
+	return node
+}
+
+// suitableForDiet maps our diet labels onto schema.org RestrictedDiet values.
+// Keto has no schema.org equivalent and is left out.
+var suitableForDiet = map[string]string{
+	dietary.Vegan:      "https://schema.org/VeganDiet",
+	dietary.Vegetarian: "https://schema.org/VegetarianDiet",
+}
+
+func asList(value interface{}) []string {
+	list, _ := value.([]string)
+	return list
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/db"
+)
+
+type ActivityHandler struct {
+	db       persistence.DatabaseHandler
+	cache    persistence.CacheHandler
+	activity persistence.ActivityHandler
+}
+
+func NewActivityHandler(db persistence.DatabaseHandler, cache persistence.CacheHandler, activity persistence.ActivityHandler) *ActivityHandler {
+	return &ActivityHandler{
+		db:       db,
+		cache:    cache,
+		activity: activity,
+	}
+}
+
+func (handler *ActivityHandler) AddFavorite(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	id := ctx.Param("id")
+	if _, err := requestDB(ctx, handler.db).GetRecipe(id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := handler.activity.AddFavorite(username, id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// cached recipe lists carry the favorite counts
+	requestCache(ctx, handler.cache).ClearRecipes()
+	ctx.JSON(http.StatusOK, gin.H{"message": "Recipe has been added to favorites"})
+}
+
+func (handler *ActivityHandler) RemoveFavorite(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := handler.activity.RemoveFavorite(username, ctx.Param("id")); err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	requestCache(ctx, handler.cache).ClearRecipes()
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "Recipe has been removed from favorites"})
+}
+
+// FetchFavorites lists the recipes the user has favorited, most recently
+// favorited first.
+func (handler *ActivityHandler) FetchFavorites(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	favorites, err := handler.activity.FetchFavorites(username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ids := make([]string, len(favorites))
+	for i, favorite := range favorites {
+		ids[i] = favorite.RecipeID
+	}
+
+	recipes, err := requestDB(ctx, handler.db).FetchRecipesByIDs(ids)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, recipes)
+}
+
+func (handler *ActivityHandler) LogCooking(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		Note     string    `json:"note"`
+		Photo    string    `json:"photo"`
+		CookedAt time.Time `json:"cookedAt"`
+	}
+	// the body is optional; an empty one just records the recipe as cooked now
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+			return
+		}
+	}
+
+	id := ctx.Param("id")
+	if _, err := requestDB(ctx, handler.db).GetRecipe(id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	log := persistence.CookingLog{
+		Username: username,
+		RecipeID: id,
+		Note:     request.Note,
+		Photo:    request.Photo,
+		CookedAt: request.CookedAt,
+	}
+	if err := handler.activity.AddCookingLog(&log); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, log)
+}
+
+func (handler *ActivityHandler) FetchCookingHistory(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+	page, limit, ok := parsePagination(ctx)
+	if !ok {
+		return
+	}
+
+	logs, total, err := handler.activity.FetchCookingHistory(username, (page-1)*limit, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, Page{Items: logs, Page: page, Limit: limit, Total: total})
+}
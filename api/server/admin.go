@@ -0,0 +1,26 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/server/middleware/authentication/identity"
+)
+
+// RequireAdmin lets through only the signed in users named in admins. It
+// runs after authentication, so a request without a user, such as one
+// made with the shared API key, is refused too.
+func RequireAdmin(admins []string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(admins))
+	for _, admin := range admins {
+		allowed[admin] = true
+	}
+	return func(ctx *gin.Context) {
+		if username := identity.Username(ctx); username == "" || !allowed[username] {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "This endpoint is restricted to administrators"})
+			return
+		}
+		ctx.Next()
+	}
+}
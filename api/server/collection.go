@@ -0,0 +1,294 @@
+package server
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/pdf"
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/db"
+	"github.com/tolopsy/foodpro/api/server/middleware/authentication/identity"
+)
+
+type CollectionHandler struct {
+	db          persistence.DatabaseHandler
+	collections persistence.CollectionHandler
+}
+
+func NewCollectionHandler(db persistence.DatabaseHandler, collections persistence.CollectionHandler) *CollectionHandler {
+	return &CollectionHandler{
+		db:          db,
+		collections: collections,
+	}
+}
+
+type collectionRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Visibility  string   `json:"visibility" binding:"omitempty,oneof=private shared public"`
+	SharedWith  []string `json:"sharedWith"`
+}
+
+func (request collectionRequest) toCollection() persistence.Collection {
+	collection := persistence.Collection{
+		Name:        request.Name,
+		Description: request.Description,
+		Visibility:  request.Visibility,
+		SharedWith:  request.SharedWith,
+	}
+	if collection.Visibility == "" {
+		collection.Visibility = persistence.Private
+	}
+	return collection
+}
+
+// readableCollection fetches a collection the requesting user, who may be
+// anonymous, is allowed to see. Collections the user cannot see are
+// reported as missing so their existence is not revealed.
+func (handler *CollectionHandler) readableCollection(ctx *gin.Context, id string) (persistence.Collection, bool) {
+	collection, err := handler.collections.GetCollection(id)
+	if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return collection, false
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return collection, false
+	}
+	if !collection.ReadableBy(identity.Username(ctx)) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": db.ErrorNotFound.Error()})
+		return collection, false
+	}
+	return collection, true
+}
+
+// ownedCollection fetches a collection the user owns, reporting any other
+// collection as missing.
+func (handler *CollectionHandler) ownedCollection(ctx *gin.Context, username string) (persistence.Collection, bool) {
+	collection, err := handler.collections.GetCollection(ctx.Param("id"))
+	if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return collection, false
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return collection, false
+	}
+	if collection.Owner != username {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": db.ErrorNotFound.Error()})
+		return collection, false
+	}
+	return collection, true
+}
+
+// respondToChange maps the result of an owner-only change to a response.
+func respondToChange(ctx *gin.Context, err error, message string) {
+	if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": message})
+}
+
+func (handler *CollectionHandler) CreateCollection(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var request collectionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+		return
+	}
+
+	collection := request.toCollection()
+	collection.Owner = username
+	if err := handler.collections.AddCollection(&collection); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, collection)
+}
+
+func (handler *CollectionHandler) FetchCollections(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	collections, err := handler.collections.FetchCollections(username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, collections)
+}
+
+// FetchOneCollection serves a collection as JSON, or every recipe in it as
+// a printable PDF when the id ends in .pdf.
+func (handler *CollectionHandler) FetchOneCollection(ctx *gin.Context) {
+	id, asPDF := pdfID(ctx.Param("id"))
+	var options pdf.Options
+	if asPDF {
+		var ok bool
+		if options, ok = printOptions(ctx); !ok {
+			return
+		}
+	}
+	collection, ok := handler.readableCollection(ctx, id)
+	if !ok {
+		return
+	}
+	if !asPDF {
+		ctx.JSON(http.StatusOK, collection)
+		return
+	}
+
+	recipes, err := requestDB(ctx, handler.db).FetchRecipesByIDs(collection.RecipeIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	respondWithPDF(ctx, "collection.pdf", func(writer io.Writer) error {
+		return pdf.RenderCollection(writer, collection, recipes, options)
+	})
+}
+
+// FetchCollectionRecipes serves the recipes of a collection, in order, one
+// page at a time.
+func (handler *CollectionHandler) FetchCollectionRecipes(ctx *gin.Context) {
+	page, limit, ok := parsePagination(ctx)
+	if !ok {
+		return
+	}
+	collection, ok := handler.readableCollection(ctx, ctx.Param("id"))
+	if !ok {
+		return
+	}
+
+	start, end := pageBounds(page, limit, len(collection.RecipeIDs))
+	recipes, err := requestDB(ctx, handler.db).FetchRecipesByIDs(collection.RecipeIDs[start:end])
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, Page{Items: recipes, Page: page, Limit: limit, Total: len(collection.RecipeIDs)})
+}
+
+func (handler *CollectionHandler) UpdateCollection(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var request collectionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+		return
+	}
+
+	err := handler.collections.UpdateCollection(username, ctx.Param("id"), request.toCollection())
+	respondToChange(ctx, err, "Collection has been updated")
+}
+
+func (handler *CollectionHandler) AddCollectionRecipe(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		RecipeID string `json:"recipeId" binding:"required"`
+		// zero-based position to insert at; the recipe is appended if omitted
+		Position *int `json:"position" binding:"omitempty,min=0"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+		return
+	}
+	collection, ok := handler.ownedCollection(ctx, username)
+	if !ok {
+		return
+	}
+	for _, id := range collection.RecipeIDs {
+		if id == request.RecipeID {
+			ctx.JSON(http.StatusConflict, gin.H{"error": "Recipe is already in the collection"})
+			return
+		}
+	}
+	if _, err := requestDB(ctx, handler.db).GetRecipe(request.RecipeID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Recipe " + request.RecipeID + " -> " + err.Error()})
+		return
+	}
+
+	position := -1
+	if request.Position != nil {
+		position = *request.Position
+	}
+	err := handler.collections.AddCollectionRecipe(username, ctx.Param("id"), request.RecipeID, position)
+	respondToChange(ctx, err, "Recipe has been added to the collection")
+}
+
+func (handler *CollectionHandler) RemoveCollectionRecipe(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	err := handler.collections.RemoveCollectionRecipe(username, ctx.Param("id"), ctx.Param("recipeId"))
+	respondToChange(ctx, err, "Recipe has been removed from the collection")
+}
+
+func (handler *CollectionHandler) ReorderCollection(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		RecipeIDs []string `json:"recipeIds" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+		return
+	}
+	seen := make(map[string]bool, len(request.RecipeIDs))
+	for _, id := range request.RecipeIDs {
+		if seen[id] {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Recipe " + id + " is listed more than once"})
+			return
+		}
+		seen[id] = true
+	}
+	if _, ok := handler.ownedCollection(ctx, username); !ok {
+		return
+	}
+
+	// the collection changing since it was read also shows up as not found
+	err := handler.collections.ReorderCollection(username, ctx.Param("id"), request.RecipeIDs)
+	if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "recipeIds must list exactly the recipes in the collection"})
+		return
+	}
+	respondToChange(ctx, err, "Collection has been reordered")
+}
+
+func (handler *CollectionHandler) DeleteCollection(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := handler.collections.DeleteCollection(username, ctx.Param("id")); err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "Collection has been deleted"})
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+)
+
+// recipeETag formats a recipe version as a strong entity tag.
+func recipeETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// etagMatches reports whether the current entity tag is listed in an
+// If-Match or If-None-Match header value. Weak comparison ignores the W/
+// prefix, as required for If-None-Match.
+func etagMatches(header, current string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == current {
+			return true
+		}
+	}
+	return false
+}
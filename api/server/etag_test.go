@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/db"
+)
+
+func TestRecipeETag(t *testing.T) {
+	tests := map[int64]string{1: `"1"`, 42: `"42"`, 0: `"0"`}
+	for version, want := range tests {
+		if got := recipeETag(version); got != want {
+			t.Errorf("recipeETag(%d) = %s, want %s", version, got, want)
+		}
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	tests := []struct {
+		header string
+		weak   bool
+		want   bool
+	}{
+		{`"3"`, false, true},
+		{`"4"`, false, false},
+		{`*`, false, true},
+		{`*`, true, true},
+		{`W/"3"`, true, true},
+		{`W/"3"`, false, false},
+		{`"1", "2",  "3"`, false, true},
+		{`"1","2"`, false, false},
+		{`"1", W/"3"`, true, true},
+		{`"1", W/"3"`, false, false},
+		{`3`, false, false},
+	}
+	for _, test := range tests {
+		if got := etagMatches(test.header, `"3"`, test.weak); got != test.want {
+			t.Errorf("etagMatches(%s, \"3\", %v) = %v, want %v", test.header, test.weak, got, test.want)
+		}
+	}
+}
+
+// recipeStore serves GetRecipe from a map and leaves the rest of
+// persistence.DatabaseHandler unimplemented.
+type recipeStore struct {
+	persistence.DatabaseHandler
+	recipes map[string]persistence.Recipe
+}
+
+func (store recipeStore) WithContext(context.Context) persistence.DatabaseHandler {
+	return store
+}
+
+func (store recipeStore) GetRecipe(id string) (persistence.Recipe, error) {
+	recipe, ok := store.recipes[id]
+	if !ok {
+		return persistence.Recipe{}, db.ErrorNotFound
+	}
+	return recipe, nil
+}
+
+func TestExpectedVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := &Handler{db: recipeStore{recipes: map[string]persistence.Recipe{"stew": {ID: "stew", Version: 3}}}}
+
+	tests := []struct {
+		id, ifMatch string
+		version     int64
+		ok          bool
+		status      int
+	}{
+		{"stew", "", persistence.AnyVersion, true, http.StatusOK},
+		{"missing", "", persistence.AnyVersion, true, http.StatusOK},
+		{"stew", `"3"`, 3, true, http.StatusOK},
+		{"stew", `*`, 3, true, http.StatusOK},
+		{"stew", `"2"`, 0, false, http.StatusPreconditionFailed},
+		{"stew", `W/"3"`, 0, false, http.StatusPreconditionFailed},
+		{"missing", `"3"`, 0, false, http.StatusNotFound},
+	}
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = httptest.NewRequest(http.MethodPut, "/recipes/"+test.id, nil)
+		if test.ifMatch != "" {
+			ctx.Request.Header.Set("If-Match", test.ifMatch)
+		}
+
+		version, ok := handler.expectedVersion(ctx, test.id)
+		if version != test.version || ok != test.ok || recorder.Code != test.status {
+			t.Errorf("expectedVersion(%s, If-Match: %s) = %d, %v with status %d, want %d, %v with status %d",
+				test.id, test.ifMatch, version, ok, recorder.Code, test.version, test.ok, test.status)
+		}
+	}
+}
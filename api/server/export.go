@@ -0,0 +1,76 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/exporter"
+)
+
+// ExportRecipe renders a single recipe as schema.org JSON-LD, Markdown,
+// plain text, a Paprika recipe file or Meal-Master text, chosen with the
+// format query parameter. download=true asks the browser to save it.
+func (handler *Handler) ExportRecipe(ctx *gin.Context) {
+	format := exporter.Format(ctx.DefaultQuery("format", string(exporter.JSONLD)))
+	switch format {
+	case exporter.JSONLD, exporter.Markdown, exporter.Text, exporter.Paprika, exporter.MealMaster:
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": exporter.ErrorUnknownFormat.Error()})
+		return
+	}
+
+	id := ctx.Param("id")
+	recipe, err := requestDB(ctx, handler.db).GetRecipe(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Paprika files are always downloaded since browsers cannot show them.
+	if format == exporter.Paprika || ctx.Query("download") == "true" {
+		ctx.Header("Content-Disposition", `attachment; filename="`+exporter.Filename(recipe, format)+`"`)
+	}
+	ctx.Header("ETag", recipeETag(recipe.Version))
+	ctx.Header("Content-Type", format.ContentType())
+	ctx.Status(http.StatusOK)
+	if err := exporter.Write(ctx.Writer, format, recipe, requestURL(ctx, "/recipes/"+id)); err != nil {
+		handler.log(ctx).Error("Error while exporting recipe", slog.String("recipe", id), slog.String("error", err.Error()))
+	}
+}
+
+// ExportAllRecipes streams a backup of every recipe as NDJSON or a zip
+// archive. Recipes are written as they are read, so once the response has
+// started a failure can only be logged and the body will be truncated.
+func (handler *Handler) ExportAllRecipes(ctx *gin.Context) {
+	format := exporter.BackupFormat(ctx.DefaultQuery("format", string(exporter.NDJSON)))
+	var contentType string
+	switch format {
+	case exporter.NDJSON:
+		contentType = "application/x-ndjson"
+	case exporter.Zip:
+		contentType = "application/zip"
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": exporter.ErrorUnknownBackupFormat.Error()})
+		return
+	}
+
+	filename := "recipes-" + time.Now().UTC().Format("20060102-150405") + "." + string(format)
+	ctx.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	ctx.Header("Content-Type", contentType)
+	ctx.Status(http.StatusOK)
+	if err := exporter.WriteBackup(ctx.Writer, format, requestDB(ctx, handler.db).EachRecipe); err != nil {
+		handler.log(ctx).Error("Error while exporting recipes", slog.String("error", err.Error()))
+	}
+}
+
+// requestURL builds an absolute URL on the host the request was made to.
+func requestURL(ctx *gin.Context, path string) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + ctx.Request.Host + path
+}
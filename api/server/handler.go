@@ -1,69 +1,183 @@
 package server
 
 import (
-	"log"
+	"io"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/tolopsy/foodpro/api/exporter"
+	"github.com/tolopsy/foodpro/api/logging"
+	"github.com/tolopsy/foodpro/api/nutrition"
+	"github.com/tolopsy/foodpro/api/pdf"
 	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/blob"
 	"github.com/tolopsy/foodpro/api/persistence/cache"
+	"github.com/tolopsy/foodpro/api/persistence/db"
+	"github.com/tolopsy/foodpro/api/units"
 )
 
 type Handler struct {
-	db    persistence.DatabaseHandler
-	cache persistence.CacheHandler
+	db     persistence.DatabaseHandler
+	cache  persistence.CacheHandler
+	blobs  persistence.BlobStore
+	foods  *nutrition.Table
+	logger *slog.Logger
 }
 
-func NewHandler(db persistence.DatabaseHandler, cache persistence.CacheHandler) *Handler {
+func NewHandler(db persistence.DatabaseHandler, cache persistence.CacheHandler, blobs persistence.BlobStore, foods *nutrition.Table, logger *slog.Logger) *Handler {
 	return &Handler{
-		db:    db,
-		cache: cache,
+		db:     db,
+		cache:  cache,
+		blobs:  blobs,
+		foods:  foods,
+		logger: logger,
 	}
 }
 
+// log returns the logger for the request, carrying its ID, route and user.
+func (handler *Handler) log(ctx *gin.Context) *slog.Logger {
+	return logging.Request(ctx, handler.logger)
+}
+
 func (handler *Handler) FetchAllRecipes(ctx *gin.Context) {
-	fetchFromDB := false
-	recipes, err := handler.cache.GetRecipes()
-	if err == cache.ErrorKeyDoesNotExist {
-		fetchFromDB = true
-	} else if err != nil {
-		log.Println("Error while fetching recipes from cache -> " + err.Error())
-		fetchFromDB = true
+	sortBy := ctx.Query("sort")
+	if sortBy != "" && sortBy != "rating" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "recipes can only be sorted by rating"})
+		return
 	}
 
-	if fetchFromDB {
-		recipes, err = handler.db.FetchAllRecipes()
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-		handler.cache.SetRecipes(recipes)
+	recipes, err := cache.Recipes(requestDB(ctx, handler.db), requestCache(ctx, handler.cache), handler.log(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 
+	if sortBy == "rating" {
+		persistence.SortByRating(recipes)
+	}
 	ctx.JSON(http.StatusOK, recipes)
 }
 
+// FetchOneRecipe serves a recipe as JSON, or as a printable PDF when the id
+// ends in .pdf.
 func (handler *Handler) FetchOneRecipe(ctx *gin.Context) {
-	id := ctx.Param("id")
-	recipe, err := handler.db.GetRecipe(id)
+	id, asPDF := pdfID(ctx.Param("id"))
+	var options pdf.Options
+	if asPDF {
+		var ok bool
+		if options, ok = printOptions(ctx); !ok {
+			return
+		}
+	}
+	var servings int
+	if value := ctx.Query("servings"); value != "" {
+		var err error
+		if servings, err = strconv.Atoi(value); err != nil || servings <= 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "servings must be a positive whole number"})
+			return
+		}
+	}
+	system := units.System(ctx.Query("units"))
+	if system != "" && system != units.Metric && system != units.Imperial {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "units must be either metric or imperial"})
+		return
+	}
+
+	recipe, err := requestDB(ctx, handler.db).GetRecipe(id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	etag := recipeETag(recipe.Version)
+	ctx.Header("ETag", etag)
+	if ifNoneMatch := ctx.GetHeader("If-None-Match"); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag, true) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	if servings > 0 || system != "" {
+		if recipe, err = recipe.Scale(servings, system); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if asPDF {
+		respondWithPDF(ctx, exporter.Slug(recipe)+pdfSuffix, func(writer io.Writer) error {
+			return pdf.RenderRecipe(writer, recipe, options)
+		})
+		return
+	}
 	ctx.JSON(http.StatusOK, recipe)
 }
 
+// expectedVersion resolves the request's If-Match header to the recipe version
+// a write is conditioned on. It writes a response and returns false if the
+// precondition already fails.
+func (handler *Handler) expectedVersion(ctx *gin.Context, id string) (int64, bool) {
+	ifMatch := ctx.GetHeader("If-Match")
+	if ifMatch == "" {
+		return persistence.AnyVersion, true
+	}
+
+	current, err := requestDB(ctx, handler.db).GetRecipe(id)
+	if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return 0, false
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return 0, false
+	}
+	if !etagMatches(ifMatch, recipeETag(current.Version), false) {
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{"error": "Recipe has been modified"})
+		return 0, false
+	}
+	return current.Version, true
+}
+
+func (handler *Handler) FetchRecipeNutrition(ctx *gin.Context) {
+	id := ctx.Param("id")
+	recipe, err := requestDB(ctx, handler.db).GetRecipe(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.foods.Calculate(recipe))
+}
+
 func (handler *Handler) SearchRecipesByTag(ctx *gin.Context) {
-	tag := ctx.Query("tag")
-	recipes, err := handler.db.FindRecipesByTag(tag)
+	filter := persistence.RecipeFilter{
+		Tag:              ctx.Query("tag"),
+		Diets:            queryList(ctx, "diet"),
+		ExcludeAllergens: queryList(ctx, "allergenFree"),
+	}
+	recipes, err := requestDB(ctx, handler.db).SearchRecipes(filter)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
 	}
 	ctx.JSON(http.StatusOK, recipes)
 }
 
+// queryList collects a query parameter given either repeatedly or as a
+// comma separated list.
+func queryList(ctx *gin.Context, key string) []string {
+	var values []string
+	for _, value := range ctx.QueryArray(key) {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, strings.ToLower(item))
+			}
+		}
+	}
+	return values
+}
+
 func (handler *Handler) CreateNewRecipe(ctx *gin.Context) {
 	var recipe persistence.Recipe
 	if err := ctx.ShouldBindJSON(&recipe); err != nil {
@@ -71,11 +185,11 @@ func (handler *Handler) CreateNewRecipe(ctx *gin.Context) {
 		return
 	}
 
-	if err := handler.db.AddRecipe(&recipe); err != nil {
+	if err := requestDB(ctx, handler.db).AddRecipe(&recipe); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	handler.cache.ClearRecipes()
+	requestCache(ctx, handler.cache).ClearRecipes()
 	ctx.JSON(http.StatusOK, recipe)
 }
 
@@ -87,22 +201,46 @@ func (handler *Handler) UpdateRecipe(ctx *gin.Context) {
 		return
 	}
 
-	if err := handler.db.UpdateRecipe(id, recipe); err != nil {
+	version, ok := handler.expectedVersion(ctx, id)
+	if !ok {
+		return
+	}
+
+	if err := requestDB(ctx, handler.db).UpdateRecipe(id, recipe, version); err == db.ErrorVersionMismatch {
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{"error": "Recipe has been modified"})
+		return
+	} else if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	handler.cache.ClearRecipes()
+	requestCache(ctx, handler.cache).ClearRecipes()
 	ctx.JSON(http.StatusOK, gin.H{"message": "Recipe has been updated"})
 }
 
 func (handler *Handler) DeleteRecipe(ctx *gin.Context) {
 	id := ctx.Param("id")
-	if err := handler.db.DeleteRecipe(id); err != nil {
+	version, ok := handler.expectedVersion(ctx, id)
+	if !ok {
+		return
+	}
+
+	images, err := requestDB(ctx, handler.db).DeleteRecipe(id, version)
+	blob.DeleteImages(handler.blobs, images)
+	if err == db.ErrorVersionMismatch {
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{"error": "Recipe has been modified"})
+		return
+	} else if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	handler.cache.ClearRecipes()
+	requestCache(ctx, handler.cache).ClearRecipes()
 	ctx.JSON(http.StatusNoContent, gin.H{"message": "Recipe has been deleted"})
 }
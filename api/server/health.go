@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// probeTimeout bounds each dependency check, so a hung dependency fails
+// the probe rather than the orchestrator's own timeout.
+const probeTimeout = 2 * time.Second
+
+// Dependency is something the API needs in order to serve requests.
+type Dependency interface {
+	Ping(context.Context) error
+	ServerVersion(context.Context) (string, error)
+}
+
+type HealthHandler struct {
+	dependencies map[string]Dependency
+	started      time.Time
+}
+
+// NewHealthHandler reports on the named dependencies, such as
+// {"mongodb": db, "redis": cache}.
+func NewHealthHandler(dependencies map[string]Dependency) *HealthHandler {
+	return &HealthHandler{dependencies: dependencies, started: time.Now()}
+}
+
+type dependencyStatus struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latencyMs"`
+	Version   string  `json:"version,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Live answers as long as the process is serving HTTP. It deliberately
+// checks nothing else, so a dependency outage does not get the API
+// restarted.
+func (handler *HealthHandler) Live(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready pings every dependency and answers 503 if any is unreachable, so
+// traffic is held back until they all are.
+func (handler *HealthHandler) Ready(ctx *gin.Context) {
+	statuses, ready := handler.check(ctx.Request.Context(), false)
+	checks := make(map[string]string, len(statuses))
+	for name, status := range statuses {
+		checks[name] = status.Status
+		if status.Error != "" {
+			checks[name] += ": " + status.Error
+		}
+	}
+	if !ready {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "checks": checks})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"status": "ok", "checks": checks})
+}
+
+// Status details every dependency's latency and version along with the
+// build and uptime of the API itself, for operators.
+func (handler *HealthHandler) Status(ctx *gin.Context) {
+	statuses, ready := handler.check(ctx.Request.Context(), true)
+	status := "ok"
+	if !ready {
+		status = "degraded"
+	}
+
+	version := "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		version = info.Main.Version
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":       status,
+		"version":      version,
+		"goVersion":    runtime.Version(),
+		"startedAt":    handler.started.UTC(),
+		"uptime":       time.Since(handler.started).Round(time.Second).String(),
+		"dependencies": statuses,
+	})
+}
+
+// check probes every dependency concurrently and reports whether all of
+// them answered.
+func (handler *HealthHandler) check(parent context.Context, withVersion bool) (map[string]dependencyStatus, bool) {
+	var mutex sync.Mutex
+	var wait sync.WaitGroup
+	statuses := make(map[string]dependencyStatus, len(handler.dependencies))
+	ready := true
+
+	for name, dependency := range handler.dependencies {
+		wait.Add(1)
+		go func(name string, dependency Dependency) {
+			defer wait.Done()
+			ctx, cancel := context.WithTimeout(parent, probeTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := dependency.Ping(ctx)
+			status := dependencyStatus{Status: "up", LatencyMS: float64(time.Since(start).Microseconds()) / 1000}
+			if err != nil {
+				status.Status, status.Error = "down", err.Error()
+			} else if withVersion {
+				status.Version, _ = dependency.ServerVersion(ctx)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			statuses[name] = status
+			if err != nil {
+				ready = false
+			}
+		}(name, dependency)
+	}
+	wait.Wait()
+	return statuses, ready
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/xid"
+
+	"github.com/tolopsy/foodpro/api/imaging"
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/blob"
+	"github.com/tolopsy/foodpro/api/persistence/db"
+)
+
+type ImageHandler struct {
+	db      persistence.DatabaseHandler
+	cache   persistence.CacheHandler
+	blobs   persistence.BlobStore
+	maxSize int64
+}
+
+func NewImageHandler(db persistence.DatabaseHandler, cache persistence.CacheHandler, blobs persistence.BlobStore, maxSize int64) *ImageHandler {
+	return &ImageHandler{
+		db:      db,
+		cache:   cache,
+		blobs:   blobs,
+		maxSize: maxSize,
+	}
+}
+
+// UploadRecipeImage accepts a JPEG or PNG in the "image" field of a
+// multipart form, stores it with its medium and thumbnail renditions and
+// attaches it to the recipe.
+func (handler *ImageHandler) UploadRecipeImage(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if _, err := requestDB(ctx, handler.db).GetRecipe(id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// leave room for the multipart framing around the file itself
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, handler.maxSize+1<<20)
+	header, err := ctx.FormFile("image")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while reading image -> " + err.Error()})
+		return
+	}
+	if header.Size > handler.maxSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Image is larger than the allowed size"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while reading image -> " + err.Error()})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, handler.maxSize))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while reading image -> " + err.Error()})
+		return
+	}
+
+	img, contentType, err := imaging.Decode(data)
+	if err == imaging.ErrorUnsupportedType {
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while decoding image -> " + err.Error()})
+		return
+	}
+
+	imageId := xid.New().String()
+	prefix := "recipes/" + id + "/" + imageId + "/"
+	extension := imaging.Extension(contentType)
+	bounds := img.Bounds()
+	image := persistence.RecipeImage{
+		ID:          imageId,
+		ContentType: contentType,
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+	}
+
+	originalKey := prefix + "original" + extension
+	if err = handler.blobs.Put(originalKey, bytes.NewReader(data), contentType); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	image.URL, image.Keys = handler.blobs.URL(originalKey), []string{originalKey}
+
+	for _, size := range []imaging.Size{imaging.Medium, imaging.Thumbnail} {
+		rendition, err := imaging.Render(img, contentType, size)
+		if err == nil {
+			key := prefix + size.Name + extension
+			if err = handler.blobs.Put(key, bytes.NewReader(rendition.Data), contentType); err == nil {
+				image.Keys = append(image.Keys, key)
+				if size == imaging.Medium {
+					image.MediumURL = handler.blobs.URL(key)
+				} else {
+					image.ThumbnailURL = handler.blobs.URL(key)
+				}
+				continue
+			}
+		}
+		handler.deleteBlobs(image.Keys)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = requestDB(ctx, handler.db).AddRecipeImage(id, image); err != nil {
+		handler.deleteBlobs(image.Keys)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	requestCache(ctx, handler.cache).ClearRecipes()
+	ctx.JSON(http.StatusCreated, image)
+}
+
+func (handler *ImageHandler) DeleteRecipeImage(ctx *gin.Context) {
+	image, err := requestDB(ctx, handler.db).RemoveRecipeImage(ctx.Param("id"), ctx.Param("imageId"))
+	if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	handler.deleteBlobs(image.Keys)
+	requestCache(ctx, handler.cache).ClearRecipes()
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "Image has been deleted"})
+}
+
+// ServeImage streams a stored blob. Only needed for stores that do not
+// serve their blobs themselves, like the local filesystem.
+func (handler *ImageHandler) ServeImage(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	reader, contentType, err := handler.blobs.Get(key)
+	if err == blob.ErrorBlobDoesNotExist || err == blob.ErrorInvalidKey {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Image does not exist"})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	// blobs are never rewritten under the same key
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	ctx.DataFromReader(http.StatusOK, -1, contentType, reader, nil)
+}
+
+func (handler *ImageHandler) deleteBlobs(keys []string) {
+	for _, key := range keys {
+		handler.blobs.Delete(key)
+	}
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/importer"
+)
+
+const maxImportSize = 20 << 20
+
+// ImportRecipes stores recipes uploaded as a JSON array, a CSV file or
+// schema.org JSON-LD, either as the request body or as the "file" field of
+// a multipart form. The format comes from the format query parameter or
+// the content type. With dryRun=true the records are only validated.
+func (handler *Handler) ImportRecipes(ctx *gin.Context) {
+	dryRun := ctx.Query("dryRun") == "true"
+	batchSize := importer.DefaultBatchSize
+	if value := ctx.Query("batchSize"); value != "" {
+		var err error
+		if batchSize, err = strconv.Atoi(value); err != nil || batchSize <= 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "batchSize must be a positive whole number"})
+			return
+		}
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportSize)
+	var body io.Reader = ctx.Request.Body
+	contentType := ctx.ContentType()
+	if strings.HasPrefix(contentType, "multipart/") {
+		header, err := ctx.FormFile("file")
+		if tooLarge(err) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Import is larger than the allowed size"})
+			return
+		} else if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while reading upload -> " + err.Error()})
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while reading upload -> " + err.Error()})
+			return
+		}
+		defer file.Close()
+		body, contentType = file, header.Header.Get("Content-Type")
+	}
+
+	format := importer.Format(ctx.Query("format"))
+	if format == "" {
+		var ok bool
+		if format, ok = importer.FormatFromContentType(contentType); !ok {
+			ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"error": importer.ErrorUnknownFormat.Error()})
+			return
+		}
+	}
+
+	records, err := importer.Parse(format, body)
+	if err == importer.ErrorUnknownFormat {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	} else if tooLarge(err) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Import is larger than the allowed size"})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing import -> " + err.Error()})
+		return
+	}
+
+	report, err := importer.Import(requestDB(ctx, handler.db), records, batchSize, dryRun)
+	if report.Imported > 0 {
+		requestCache(ctx, handler.cache).ClearRecipes()
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "report": report})
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+const maxHTMLImportSize = 5 << 20
+
+// ImportRecipeHTML extracts a draft recipe from a web page the client
+// uploads, either as the request body or as the "file" field of a
+// multipart form. Nothing is stored: the draft is returned so it can be
+// reviewed and then saved with CreateNewRecipe.
+func (handler *Handler) ImportRecipeHTML(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxHTMLImportSize)
+	var body io.Reader = ctx.Request.Body
+	if strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		header, err := ctx.FormFile("file")
+		if tooLarge(err) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Page is larger than the allowed size"})
+			return
+		} else if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while reading upload -> " + err.Error()})
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while reading upload -> " + err.Error()})
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	draft, err := importer.ParseHTML(body)
+	if err == importer.ErrorNoRecipeFound {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	} else if tooLarge(err) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Page is larger than the allowed size"})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing page -> " + err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, draft)
+}
+
+// tooLarge reports whether err comes from reading past the limit of an
+// http.MaxBytesReader.
+func tooLarge(err error) bool {
+	var maxBytesError *http.MaxBytesError
+	return errors.As(err, &maxBytesError)
+}
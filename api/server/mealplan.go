@@ -0,0 +1,311 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/mealplan"
+	"github.com/tolopsy/foodpro/api/pdf"
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/db"
+)
+
+type MealPlanHandler struct {
+	db    persistence.DatabaseHandler
+	plans persistence.MealPlanHandler
+}
+
+func NewMealPlanHandler(db persistence.DatabaseHandler, plans persistence.MealPlanHandler) *MealPlanHandler {
+	return &MealPlanHandler{
+		db:    db,
+		plans: plans,
+	}
+}
+
+type mealPlanRequest struct {
+	Name    string                      `json:"name" binding:"required"`
+	Entries []persistence.MealPlanEntry `json:"entries" binding:"dive"`
+}
+
+type generateMealPlanRequest struct {
+	Name         string              `json:"name" binding:"required"`
+	StartDate    string              `json:"startDate" binding:"required"`
+	Days         int                 `json:"days" binding:"omitempty,min=1,max=31"`
+	Slots        []string            `json:"slots" binding:"dive,oneof=breakfast lunch dinner snack"`
+	Tags         []string            `json:"tags"`
+	SlotTags     map[string][]string `json:"slotTags"`
+	Diets        []string            `json:"diets"`
+	AllergenFree []string            `json:"allergenFree"`
+	Servings     int                 `json:"servings" binding:"omitempty,min=1"`
+	AllowRepeats bool                `json:"allowRepeats"`
+	Seed         *int64              `json:"seed"`
+}
+
+// fillEntries checks that every entry has a valid date and an existing
+// recipe, and records the recipe names so plans can be shown without
+// fetching every recipe.
+func (handler *MealPlanHandler) fillEntries(ctx *gin.Context, entries []persistence.MealPlanEntry) error {
+	for i, entry := range entries {
+		if _, err := time.Parse(mealplan.DateLayout, entry.Date); err != nil {
+			return fmt.Errorf("entry %d: date must be formatted as YYYY-MM-DD", i)
+		}
+		recipe, err := requestDB(ctx, handler.db).GetRecipe(entry.RecipeID)
+		if err != nil {
+			return fmt.Errorf("entry %d: recipe %s -> %s", i, entry.RecipeID, err.Error())
+		}
+		entries[i].RecipeName = recipe.Name
+	}
+	return nil
+}
+
+func (handler *MealPlanHandler) CreateMealPlan(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var request mealPlanRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+		return
+	}
+	if err := handler.fillEntries(ctx, request.Entries); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan := persistence.MealPlan{Owner: username, Name: request.Name, Entries: request.Entries}
+	if plan.Entries == nil {
+		plan.Entries = []persistence.MealPlanEntry{}
+	}
+	if err := handler.plans.AddMealPlan(&plan); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, plan)
+}
+
+func (handler *MealPlanHandler) FetchMealPlans(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	plans, err := handler.plans.FetchMealPlans(username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, plans)
+}
+
+// FetchOneMealPlan serves a meal plan as JSON, or its schedule and recipes
+// as a printable PDF when the id ends in .pdf.
+func (handler *MealPlanHandler) FetchOneMealPlan(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+	id, asPDF := pdfID(ctx.Param("id"))
+	var options pdf.Options
+	if asPDF {
+		if options, ok = printOptions(ctx); !ok {
+			return
+		}
+	}
+
+	plan, err := handler.plans.GetMealPlan(username, id)
+	if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !asPDF {
+		ctx.JSON(http.StatusOK, plan)
+		return
+	}
+
+	recipeIDs := make([]string, 0, len(plan.Entries))
+	for _, entry := range plan.Entries {
+		recipeIDs = append(recipeIDs, entry.RecipeID)
+	}
+	recipes, err := requestDB(ctx, handler.db).FetchRecipesByIDs(recipeIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	respondWithPDF(ctx, "meal-plan.pdf", func(writer io.Writer) error {
+		return pdf.RenderMealPlan(writer, plan, recipes, options)
+	})
+}
+
+func (handler *MealPlanHandler) UpdateMealPlan(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var request mealPlanRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+		return
+	}
+	if err := handler.fillEntries(ctx, request.Entries); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan := persistence.MealPlan{Name: request.Name, Entries: request.Entries}
+	if plan.Entries == nil {
+		plan.Entries = []persistence.MealPlanEntry{}
+	}
+	if err := handler.plans.UpdateMealPlan(username, ctx.Param("id"), plan); err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Meal plan has been updated"})
+}
+
+func (handler *MealPlanHandler) DeleteMealPlan(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := handler.plans.DeleteMealPlan(username, ctx.Param("id")); err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "Meal plan has been deleted"})
+}
+
+// candidates collects recipes carrying any of tags that also satisfy the
+// diet and allergen constraints. No tags means any recipe.
+func (handler *MealPlanHandler) candidates(ctx *gin.Context, tags, diets, allergenFree []string) ([]persistence.Recipe, error) {
+	if len(tags) == 0 {
+		tags = []string{""}
+	}
+
+	seen := make(map[interface{}]bool)
+	var recipes []persistence.Recipe
+	for _, tag := range tags {
+		found, err := requestDB(ctx, handler.db).SearchRecipes(persistence.RecipeFilter{
+			Tag:              tag,
+			Diets:            diets,
+			ExcludeAllergens: allergenFree,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, recipe := range found {
+			if !seen[recipe.ID] {
+				seen[recipe.ID] = true
+				recipes = append(recipes, recipe)
+			}
+		}
+	}
+	return recipes, nil
+}
+
+func (handler *MealPlanHandler) GenerateMealPlan(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var request generateMealPlanRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+		return
+	}
+	start, err := time.Parse(mealplan.DateLayout, request.StartDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "startDate must be formatted as YYYY-MM-DD"})
+		return
+	}
+
+	constraints := mealplan.Constraints{
+		Start:          start,
+		Days:           request.Days,
+		Slots:          request.Slots,
+		SlotCandidates: make(map[string][]persistence.Recipe),
+		Servings:       request.Servings,
+		AllowRepeats:   request.AllowRepeats,
+		Seed:           time.Now().UnixNano(),
+	}
+	if constraints.Days == 0 {
+		constraints.Days = 7
+	}
+	if len(constraints.Slots) == 0 {
+		constraints.Slots = []string{persistence.Breakfast, persistence.Lunch, persistence.Dinner}
+	}
+	if request.Seed != nil {
+		constraints.Seed = *request.Seed
+	}
+
+	if constraints.Candidates, err = handler.candidates(ctx, request.Tags, request.Diets, request.AllergenFree); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	for slot, tags := range request.SlotTags {
+		if constraints.SlotCandidates[slot], err = handler.candidates(ctx, tags, request.Diets, request.AllergenFree); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	entries, err := mealplan.Generate(constraints)
+	if err == mealplan.ErrorNoCandidates {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	plan := persistence.MealPlan{Owner: username, Name: request.Name, Entries: entries}
+	if err := handler.plans.AddMealPlan(&plan); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, plan)
+}
+
+// ExportMealPlanCalendar downloads a plan as an iCalendar file. Like every
+// authorized route it needs the user's session or token, which calendar
+// apps cannot send, so the file is meant to be imported rather than
+// subscribed to by URL.
+func (handler *MealPlanHandler) ExportMealPlanCalendar(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	id := ctx.Param("id")
+	plan, err := handler.plans.GetMealPlan(username, id)
+	if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	recipeURL := func(recipeID string) string {
+		return requestURL(ctx, "/recipes/"+recipeID)
+	}
+	ctx.Header("Content-Disposition", `attachment; filename="meal-plan.ics"`)
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(mealplan.ICalendar(plan, id, recipeURL)))
+}
@@ -1,12 +1,15 @@
 package apikey_auth
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tolopsy/foodpro/api/persistence"
 )
 
+var ErrorWrongAPIKey = errors.New("Wrong API key provided")
+
 type APIKeyAuth struct {
 	apiKey    string
 	headerKey string
@@ -24,8 +27,8 @@ func NewAPIKeyAuth(key string, verifyUser persistence.UserVerifier) *APIKeyAuth
 
 func (auth *APIKeyAuth) Authenticate() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		if ctx.GetHeader(auth.headerKey) != auth.apiKey {
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Wrong API key provided"})
+		if _, err := auth.Verify(ctx.Request.Header); err != nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			ctx.Abort()
 			return
 		}
@@ -33,6 +36,18 @@ func (auth *APIKeyAuth) Authenticate() gin.HandlerFunc {
 	}
 }
 
+func (auth *APIKeyAuth) HasCredentials(ctx *gin.Context) bool {
+	return ctx.GetHeader(auth.headerKey) != ""
+}
+
+// Verify checks the shared key, which belongs to no particular user.
+func (auth *APIKeyAuth) Verify(header http.Header) (string, error) {
+	if header.Get(auth.headerKey) != auth.apiKey {
+		return "", ErrorWrongAPIKey
+	}
+	return "", nil
+}
+
 func (auth *APIKeyAuth) SignIn(ctx *gin.Context) {
 	var user persistence.User
 	if err := ctx.ShouldBindJSON(&user); err != nil {
@@ -48,3 +63,5 @@ func (auth *APIKeyAuth) SignIn(ctx *gin.Context) {
 }
 
 func (auth *APIKeyAuth) SignOut(ctx *gin.Context) {}
+
+func (auth *APIKeyAuth) Close() error { return nil }
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"net/http"
+
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	jwt_auth "github.com/tolopsy/foodpro/api/server/middleware/authentication/jwt"
@@ -9,8 +11,31 @@ import (
 
 type AuthMiddleware interface {
 	Authenticate() gin.HandlerFunc
+	// HasCredentials reports whether the request carries credentials for
+	// this middleware, valid or not.
+	HasCredentials(*gin.Context) bool
+	// Verify checks the credentials in the headers of a request that does
+	// not go through gin, such as a gRPC call, and returns the user they
+	// belong to, which is empty for credentials not tied to a user.
+	Verify(http.Header) (string, error)
 	SignIn(*gin.Context)
 	SignOut(*gin.Context)
+	// Close releases any store the middleware keeps credentials in.
+	Close() error
+}
+
+// Optional authenticates requests that carry credentials and lets anonymous
+// ones through, for routes that serve public data to everyone and private
+// data to its owner.
+func Optional(auth AuthMiddleware) gin.HandlerFunc {
+	authenticate := auth.Authenticate()
+	return func(ctx *gin.Context) {
+		if auth.HasCredentials(ctx) {
+			authenticate(ctx)
+			return
+		}
+		ctx.Next()
+	}
 }
 
 func LoadSpecialFeatures(auth AuthMiddleware, engine *gin.Engine) {
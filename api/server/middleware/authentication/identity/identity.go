@@ -0,0 +1,18 @@
+// Package identity carries the signed in user from the authentication
+// middlewares to the handlers behind them.
+package identity
+
+import "github.com/gin-gonic/gin"
+
+const contextKey = "identity.username"
+
+// SetUsername records the authenticated user on the request context.
+func SetUsername(ctx *gin.Context, username string) {
+	ctx.Set(contextKey, username)
+}
+
+// Username returns the authenticated user, or an empty string when the
+// request was authenticated without one, e.g. with a shared API key.
+func Username(ctx *gin.Context) string {
+	return ctx.GetString(contextKey)
+}
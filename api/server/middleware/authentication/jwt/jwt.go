@@ -1,14 +1,18 @@
 package jwt_auth
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/server/middleware/authentication/identity"
 )
 
+var ErrorInvalidToken = errors.New("Invalid token")
+
 type JWTAuth struct {
 	jwtSecret  string
 	headerKey  string
@@ -75,25 +79,49 @@ func (jwtAuth *JWTAuth) SignIn(ctx *gin.Context) {
 
 func (jwtAuth *JWTAuth) Authenticate() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		tokenValue := ctx.GetHeader(jwtAuth.headerKey)
-		claims := &Claims{}
-		token, err := jwt.ParseWithClaims(tokenValue, claims, jwtAuth.getTokenSecret)
-
-		if err != nil {
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Error while parsing token ->" + err.Error()})
+		claims, err := jwtAuth.parse(ctx.GetHeader(jwtAuth.headerKey))
+		if err == ErrorInvalidToken {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			ctx.Abort()
 			return
-		}
-
-		if token == nil || !token.Valid {
-			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		} else if err != nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Error while parsing token ->" + err.Error()})
 			ctx.Abort()
 			return
 		}
 
+		identity.SetUsername(ctx, claims.Username)
 		ctx.Next()
 	}
 }
 
+// parse validates a token and returns its claims.
+func (jwtAuth *JWTAuth) parse(tokenValue string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenValue, claims, jwtAuth.getTokenSecret)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || !token.Valid {
+		return nil, ErrorInvalidToken
+	}
+	return claims, nil
+}
+
+func (jwtAuth *JWTAuth) Verify(header http.Header) (string, error) {
+	claims, err := jwtAuth.parse(header.Get(jwtAuth.headerKey))
+	if err != nil {
+		return "", err
+	}
+	return claims.Username, nil
+}
+
+func (jwtAuth *JWTAuth) HasCredentials(ctx *gin.Context) bool {
+	return ctx.GetHeader(jwtAuth.headerKey) != ""
+}
+
 // since I'm not persisting tokens on the server (yet), I'll leave this as dummy.
 func (jwtAuth *JWTAuth) SignOut(ctx *gin.Context) {}
+
+// Close does nothing, as tokens are not stored.
+func (jwtAuth *JWTAuth) Close() error { return nil }
@@ -1,15 +1,23 @@
 package session_auth
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	redisSessions "github.com/gin-contrib/sessions/redis"
 	"github.com/gin-gonic/gin"
+	"github.com/gomodule/redigo/redis"
 	"github.com/rs/xid"
 	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/server/middleware/authentication/identity"
 )
 
+var ErrorNotSignedIn = errors.New("User not logged in")
+
 type SessionAuth struct {
 	SessionName     string
 	Store           sessions.Store
@@ -45,10 +53,31 @@ func (sessionAuth *SessionAuth) Authenticate() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
+		if username, ok := session.Get(sessionAuth.userIdentifier).(string); ok {
+			identity.SetUsername(ctx, username)
+		}
 		ctx.Next()
 	}
 }
 
+func (sessionAuth *SessionAuth) HasCredentials(ctx *gin.Context) bool {
+	return sessions.Default(ctx).Get(sessionAuth.sessionTokenKey) != nil
+}
+
+// Verify reads the session named by the cookie header from the store, the
+// way the sessions middleware would for a gin request.
+func (sessionAuth *SessionAuth) Verify(header http.Header) (string, error) {
+	session, err := sessionAuth.Store.Get(&http.Request{Header: header}, sessionAuth.SessionName)
+	if err != nil {
+		return "", err
+	}
+	if session.Values[sessionAuth.sessionTokenKey] == nil {
+		return "", ErrorNotSignedIn
+	}
+	username, _ := session.Values[sessionAuth.userIdentifier].(string)
+	return username, nil
+}
+
 func (sessionAuth *SessionAuth) SignIn(ctx *gin.Context) {
 	var user persistence.User
 	if err := ctx.ShouldBindJSON(&user); err != nil {
@@ -75,3 +104,60 @@ func (sessionAuth *SessionAuth) SignOut(ctx *gin.Context) {
 	session.Save()
 	ctx.JSON(http.StatusOK, gin.H{"message": "User signed out"})
 }
+
+// Close releases the Redis connection pool behind the session store.
+func (sessionAuth *SessionAuth) Close() error {
+	redisStore, ok := sessionAuth.Store.(redisSessions.Store)
+	if !ok {
+		return nil
+	}
+	err, store := redisSessions.GetRedisStore(redisStore)
+	if err != nil {
+		return err
+	}
+	return store.Close()
+}
+
+// do runs one Redis command on the session store, giving up when ctx is done.
+func (sessionAuth *SessionAuth) do(ctx context.Context, command string, args ...interface{}) (interface{}, error) {
+	redisStore, ok := sessionAuth.Store.(redisSessions.Store)
+	if !ok {
+		return nil, errors.New("session store is not backed by Redis")
+	}
+	err, store := redisSessions.GetRedisStore(redisStore)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := store.Pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var timeout time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	return redis.DoWithTimeout(conn, timeout, command, args...)
+}
+
+// Ping checks the session store can be reached, so readiness covers the
+// routes that need a session.
+func (sessionAuth *SessionAuth) Ping(ctx context.Context) error {
+	_, err := sessionAuth.do(ctx, "PING")
+	return err
+}
+
+// ServerVersion reads redis_version from the server section of INFO.
+func (sessionAuth *SessionAuth) ServerVersion(ctx context.Context) (string, error) {
+	info, err := redis.String(sessionAuth.do(ctx, "INFO", "server"))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(info, "\n") {
+		if strings.HasPrefix(line, "redis_version:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "redis_version:")), nil
+		}
+	}
+	return "", nil
+}
@@ -1,10 +1,166 @@
 package cors_middleware
 
 import (
+	"errors"
+	"regexp"
+	"strings"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/config"
+)
+
+// Route groups with their own policy.
+const (
+	Public     = "public"
+	Authorized = "authorized"
 )
 
-func NewCorsMiddleware(rule cors.Config) gin.HandlerFunc {
-	return cors.New(rule)
-}
\ No newline at end of file
+var ErrorUnknownGroup = errors.New("no CORS policy is configured for this route group")
+
+type route struct {
+	method   string
+	segments []string
+	group    string
+}
+
+// Router applies the CORS policy of the group a request's route belongs
+// to. It has to run on the engine rather than on each group, as preflight
+// requests use OPTIONS and so never match the route they ask about; those
+// are matched on their path and Access-Control-Request-Method instead.
+type Router struct {
+	policies map[string]gin.HandlerFunc
+	routes   []route
+	assigned map[string]bool
+}
+
+func NewRouter(rules config.CORS) (*Router, error) {
+	router := &Router{policies: map[string]gin.HandlerFunc{}, assigned: map[string]bool{}}
+	for group, rule := range map[string]config.CORSRule{Public: rules.Public, Authorized: rules.Authorized} {
+		policy, err := newPolicy(rule)
+		if err != nil {
+			return nil, errors.New(group + ": " + err.Error())
+		}
+		router.policies[group] = policy
+	}
+	return router, nil
+}
+
+// Assign puts every route not yet assigned to a group into group. Call it
+// with engine.Routes() after registering each group's routes.
+func (router *Router) Assign(group string, routes gin.RoutesInfo) error {
+	if _, ok := router.policies[group]; !ok {
+		return ErrorUnknownGroup
+	}
+	for _, info := range routes {
+		key := info.Method + " " + info.Path
+		if router.assigned[key] {
+			continue
+		}
+		router.assigned[key] = true
+		router.routes = append(router.routes, route{method: info.Method, segments: split(info.Path), group: group})
+	}
+	return nil
+}
+
+// Middleware returns the handler to install with engine.Use. Requests for
+// unknown routes get no CORS headers and carry on to their 404.
+func (router *Router) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		method := ctx.Request.Method
+		if requested := ctx.GetHeader("Access-Control-Request-Method"); method == "OPTIONS" && requested != "" {
+			method = requested
+		}
+		if group, ok := router.groupOf(method, ctx.Request.URL.Path); ok {
+			router.policies[group](ctx)
+		}
+	}
+}
+
+// groupOf finds the route gin would pick for the path: at the first
+// segment where candidates differ, a static segment beats a parameter,
+// which beats a catch-all.
+func (router *Router) groupOf(method, path string) (string, bool) {
+	segments := split(path)
+	best, bestRank := "", ""
+	for _, candidate := range router.routes {
+		if candidate.method != method {
+			continue
+		}
+		if rank, ok := match(candidate.segments, segments); ok && (best == "" || rank < bestRank) {
+			best, bestRank = candidate.group, rank
+		}
+	}
+	return best, best != ""
+}
+
+// match reports whether a route template matches the path, along with a
+// rank that orders templates by how specific they are.
+func match(template, path []string) (string, bool) {
+	var rank strings.Builder
+	for i, segment := range template {
+		switch {
+		case strings.HasPrefix(segment, "*"):
+			rank.WriteByte('2')
+			return rank.String(), true
+		case i >= len(path):
+			return "", false
+		case strings.HasPrefix(segment, ":"):
+			rank.WriteByte('1')
+		case segment == path[i]:
+			rank.WriteByte('0')
+		default:
+			return "", false
+		}
+	}
+	return rank.String(), len(template) == len(path)
+}
+
+func split(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func newPolicy(rule config.CORSRule) (gin.HandlerFunc, error) {
+	corsConfig := cors.Config{
+		AllowMethods:     rule.AllowMethods,
+		AllowHeaders:     rule.AllowHeaders,
+		ExposeHeaders:    rule.ExposeHeaders,
+		AllowCredentials: rule.AllowCredentials,
+		MaxAge:           rule.MaxAge,
+	}
+	var patterns []*regexp.Regexp
+	for _, origin := range rule.AllowOrigins {
+		if origin == "*" {
+			corsConfig.AllowAllOrigins = true
+			patterns = nil
+			break
+		}
+		patterns = append(patterns, originMatcher(origin))
+	}
+	if !corsConfig.AllowAllOrigins {
+		corsConfig.AllowOriginFunc = func(origin string) bool {
+			for _, pattern := range patterns {
+				if pattern.MatchString(origin) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	if err := corsConfig.Validate(); err != nil {
+		return nil, err
+	}
+	return cors.New(corsConfig), nil
+}
+
+// originMatcher compiles an origin in which * stands for one or more host
+// labels, so https://*.example.com matches https://app.example.com but not
+// https://example.com or https://evil.com/.example.com.
+func originMatcher(origin string) *regexp.Regexp {
+	parts := strings.Split(strings.ToLower(origin), "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("(?i)^" + strings.Join(parts, `[a-z0-9-]+(\.[a-z0-9-]+)*`) + "$")
+}
@@ -0,0 +1,112 @@
+package cors_middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/config"
+)
+
+func TestOriginMatcher(t *testing.T) {
+	tests := []struct {
+		pattern, origin string
+		want            bool
+	}{
+		{"https://app.example.com", "https://app.example.com", true},
+		{"https://app.example.com", "https://APP.example.com", true},
+		{"https://app.example.com", "http://app.example.com", false},
+		{"https://app.example.com", "https://appXexample.com", false},
+		{"https://*.example.com", "https://app.example.com", true},
+		{"https://*.example.com", "https://eu.app.example.com", true},
+		{"https://*.example.com", "https://example.com", false},
+		{"https://*.example.com", "https://evil.com/.example.com", false},
+		{"https://*.example.com", "https://app.example.com.evil.com", false},
+		{"http://localhost:*", "http://localhost:3000", true},
+	}
+	for _, test := range tests {
+		if got := originMatcher(test.pattern).MatchString(test.origin); got != test.want {
+			t.Errorf("originMatcher(%q) matching %q = %v, want %v", test.pattern, test.origin, got, test.want)
+		}
+	}
+}
+
+func testRouter(t *testing.T) *Router {
+	t.Helper()
+	router, err := NewRouter(config.Default().CORS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public := gin.RoutesInfo{
+		{Method: "GET", Path: "/recipes"},
+		{Method: "GET", Path: "/recipes/:id"},
+		{Method: "GET", Path: "/images/*filepath"},
+	}
+	authorized := gin.RoutesInfo{
+		{Method: "GET", Path: "/recipes"},
+		{Method: "GET", Path: "/recipes/search"},
+		{Method: "POST", Path: "/recipes"},
+		{Method: "PUT", Path: "/recipes/:id"},
+	}
+	if err := router.Assign(Public, public); err != nil {
+		t.Fatal(err)
+	}
+	if err := router.Assign(Authorized, append(public, authorized...)); err != nil {
+		t.Fatal(err)
+	}
+	return router
+}
+
+func TestGroupOf(t *testing.T) {
+	router := testRouter(t)
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{"GET", "/recipes", Public},
+		{"GET", "/recipes/", Public},
+		{"GET", "/recipes/42", Public},
+		{"GET", "/recipes/search", Authorized},
+		{"POST", "/recipes", Authorized},
+		{"PUT", "/recipes/42", Authorized},
+		{"GET", "/images/a/b.jpg", Public},
+		{"DELETE", "/recipes/42", ""},
+		{"GET", "/recipes/42/nutrition", ""},
+		{"GET", "/unknown", ""},
+	}
+	for _, test := range tests {
+		group, ok := router.groupOf(test.method, test.path)
+		if group != test.want || ok != (test.want != "") {
+			t.Errorf("groupOf(%s %s) = %q, %v, want %q", test.method, test.path, group, ok, test.want)
+		}
+	}
+}
+
+func TestAssignUnknownGroup(t *testing.T) {
+	router := testRouter(t)
+	if err := router.Assign("internal", nil); err != ErrorUnknownGroup {
+		t.Errorf("Assign(internal) = %v, want ErrorUnknownGroup", err)
+	}
+}
+
+// Preflights are matched on the method they ask about, not OPTIONS.
+func TestMiddlewarePreflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(testRouter(t).Middleware())
+
+	request := httptest.NewRequest("OPTIONS", "/recipes", nil)
+	request.Header.Set("Origin", "http://localhost:3000")
+	request.Header.Set("Access-Control-Request-Method", "POST")
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("preflight status = %d, want %d", recorder.Code, http.StatusNoContent)
+	}
+	if got := recorder.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want the request's origin", got)
+	}
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultPageSize = 20
+const maxPageSize = 100
+
+// maxPage keeps the offset (page-1)*limit far from overflowing.
+const maxPage = 100000
+
+// Page wraps one page of a paginated listing.
+type Page struct {
+	Items interface{} `json:"items"`
+	Page  int         `json:"page"`
+	Limit int         `json:"limit"`
+	Total int         `json:"total"`
+}
+
+// parsePagination reads the page (1-based) and limit query parameters. It
+// writes a 400 response and returns false when either is invalid.
+func parsePagination(ctx *gin.Context) (int, int, bool) {
+	page, limit := 1, defaultPageSize
+	var err error
+	if value := ctx.Query("page"); value != "" {
+		if page, err = strconv.Atoi(value); err != nil || page < 1 || page > maxPage {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "page must be between 1 and " + strconv.Itoa(maxPage)})
+			return 0, 0, false
+		}
+	}
+	if value := ctx.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxPageSize {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxPageSize)})
+			return 0, 0, false
+		}
+	}
+	return page, limit, true
+}
+
+// pageBounds returns the slice bounds of a page within total items.
+func pageBounds(page, limit, total int) (int, int) {
+	start := (page - 1) * limit
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	return start, end
+}
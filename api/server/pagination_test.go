@@ -0,0 +1,64 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParsePagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		query       string
+		page, limit int
+		ok          bool
+	}{
+		{"", 1, defaultPageSize, true},
+		{"page=3", 3, defaultPageSize, true},
+		{"page=2&limit=50", 2, 50, true},
+		{"limit=100", 1, maxPageSize, true},
+		{"page=100000", maxPage, defaultPageSize, true},
+		{"page=0", 0, 0, false},
+		{"page=-1", 0, 0, false},
+		{"page=100001", 0, 0, false},
+		{"page=two", 0, 0, false},
+		{"limit=0", 0, 0, false},
+		{"limit=101", 0, 0, false},
+		{"limit=1.5", 0, 0, false},
+	}
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/feed?"+test.query, nil)
+
+		page, limit, ok := parsePagination(ctx)
+		if page != test.page || limit != test.limit || ok != test.ok {
+			t.Errorf("parsePagination(%q) = %d, %d, %v, want %d, %d, %v", test.query, page, limit, ok, test.page, test.limit, test.ok)
+		}
+		if !ok && recorder.Code != http.StatusBadRequest {
+			t.Errorf("parsePagination(%q) responded %d, want 400", test.query, recorder.Code)
+		}
+	}
+}
+
+func TestPageBounds(t *testing.T) {
+	tests := []struct {
+		page, limit, total int
+		start, end         int
+	}{
+		{1, 20, 45, 0, 20},
+		{2, 20, 45, 20, 40},
+		{3, 20, 45, 40, 45},
+		{4, 20, 45, 45, 45},
+		{1, 20, 0, 0, 0},
+		{maxPage, maxPageSize, 10, 10, 10},
+	}
+	for _, test := range tests {
+		start, end := pageBounds(test.page, test.limit, test.total)
+		if start != test.start || end != test.end {
+			t.Errorf("pageBounds(%d, %d, %d) = %d, %d, want %d, %d", test.page, test.limit, test.total, start, end, test.start, test.end)
+		}
+	}
+}
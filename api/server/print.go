@@ -0,0 +1,59 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/pdf"
+)
+
+const pdfSuffix = ".pdf"
+
+// pdfID strips the .pdf suffix that asks for a printable copy of a
+// resource, as in /recipes/:id.pdf, and reports whether it was present.
+func pdfID(id string) (string, bool) {
+	if strings.HasSuffix(id, pdfSuffix) {
+		return strings.TrimSuffix(id, pdfSuffix), true
+	}
+	return id, false
+}
+
+// printOptions reads the paper (a4 or letter) and columns (1 or 2) query
+// parameters. It writes a 400 response and returns false when either is
+// invalid.
+func printOptions(ctx *gin.Context) (pdf.Options, bool) {
+	options := pdf.Options{Paper: pdf.A4, IngredientColumns: 1}
+	if value := ctx.Query("paper"); value != "" {
+		paper, ok := pdf.PaperByName(value)
+		if !ok {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "paper must be either a4 or letter"})
+			return options, false
+		}
+		options.Paper = paper
+	}
+	switch ctx.DefaultQuery("columns", "1") {
+	case "1":
+	case "2":
+		options.IngredientColumns = 2
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "columns must be either 1 or 2"})
+		return options, false
+	}
+	return options, true
+}
+
+// respondWithPDF renders a document in full before answering, so a
+// rendering failure can still be reported as an error response.
+func respondWithPDF(ctx *gin.Context, filename string, render func(io.Writer) error) {
+	var document bytes.Buffer
+	if err := render(&document); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Error while rendering PDF -> " + err.Error()})
+		return
+	}
+	ctx.Header("Content-Disposition", `inline; filename="`+filename+`"`)
+	ctx.Data(http.StatusOK, "application/pdf", document.Bytes())
+}
@@ -0,0 +1,18 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// requestDB binds database to the request's context, so its calls are
+// cancelled along with the request and appear in the request's trace.
+func requestDB(ctx *gin.Context, database persistence.DatabaseHandler) persistence.DatabaseHandler {
+	return database.WithContext(ctx.Request.Context())
+}
+
+// requestCache is requestDB for the recipe cache.
+func requestCache(ctx *gin.Context, cache persistence.CacheHandler) persistence.CacheHandler {
+	return cache.WithContext(ctx.Request.Context())
+}
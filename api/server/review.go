@@ -0,0 +1,85 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/db"
+)
+
+type ReviewHandler struct {
+	db      persistence.DatabaseHandler
+	cache   persistence.CacheHandler
+	reviews persistence.ReviewHandler
+}
+
+func NewReviewHandler(db persistence.DatabaseHandler, cache persistence.CacheHandler, reviews persistence.ReviewHandler) *ReviewHandler {
+	return &ReviewHandler{
+		db:      db,
+		cache:   cache,
+		reviews: reviews,
+	}
+}
+
+// SaveReview creates or edits the signed in user's review of a recipe.
+func (handler *ReviewHandler) SaveReview(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var review persistence.Review
+	if err := ctx.ShouldBindJSON(&review); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+		return
+	}
+	review.RecipeID = ctx.Param("id")
+	review.Username = username
+
+	if _, err := requestDB(ctx, handler.db).GetRecipe(review.RecipeID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err := handler.reviews.SaveReview(&review); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// cached recipe lists carry the rating aggregates
+	requestCache(ctx, handler.cache).ClearRecipes()
+	ctx.JSON(http.StatusOK, review)
+}
+
+func (handler *ReviewHandler) FetchReviews(ctx *gin.Context) {
+	page, limit, ok := parsePagination(ctx)
+	if !ok {
+		return
+	}
+
+	reviews, total, err := handler.reviews.FetchReviews(ctx.Param("id"), (page-1)*limit, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, Page{Items: reviews, Page: page, Limit: limit, Total: total})
+}
+
+func (handler *ReviewHandler) DeleteReview(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := handler.reviews.DeleteReview(ctx.Param("id"), username); err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	requestCache(ctx, handler.cache).ClearRecipes()
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "Review has been deleted"})
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+
+	graphql_api "github.com/tolopsy/foodpro/api/graphql"
+	"github.com/tolopsy/foodpro/api/logging"
+	"github.com/tolopsy/foodpro/api/metrics"
+	"github.com/tolopsy/foodpro/api/openapi"
+	auth "github.com/tolopsy/foodpro/api/server/middleware/authentication"
+	cors_middleware "github.com/tolopsy/foodpro/api/server/middleware/cors"
+	"github.com/tolopsy/foodpro/api/tracing"
+)
+
+// Routes are the handlers and middlewares the API is served by.
+type Routes struct {
+	Recipes       *Handler
+	ShoppingLists *ShoppingHandler
+	MealPlans     *MealPlanHandler
+	Collections   *CollectionHandler
+	Reviews       *ReviewHandler
+	Activity      *ActivityHandler
+	Images        *ImageHandler
+	Health        *HealthHandler
+	GraphQL       *graphql_api.Handler
+	Auth          auth.AuthMiddleware
+	CORS          *cors_middleware.Router
+	Logger        *slog.Logger
+	// usernames allowed to read /status
+	Admins []string
+}
+
+// NewEngine registers every route of the API on a new engine. Every route
+// registered here must be described in the OpenAPI document, which
+// routes_test.go checks.
+func NewEngine(routes Routes) *gin.Engine {
+	engine := gin.New()
+	engine.Use(logging.Recovery(routes.Logger))
+	// probes are registered ahead of the middlewares so they stay cheap
+	engine.GET("/healthz", routes.Health.Live)
+	engine.GET("/readyz", routes.Health.Ready)
+	engine.GET("/metrics", metrics.Handler())
+	engine.Use(metrics.Middleware())
+	engine.Use(tracing.Middleware())
+	engine.Use(logging.Middleware(routes.Logger))
+	engine.Use(routes.CORS.Middleware())
+	auth.LoadSpecialFeatures(routes.Auth, engine)
+	// authentication endpoints follow the authorized policy
+	routes.CORS.Assign(cors_middleware.Authorized, engine.Routes())
+
+	engine.GET("/recipes", routes.Recipes.FetchAllRecipes)
+	engine.GET("recipes/:id", routes.Recipes.FetchOneRecipe)
+	engine.GET("/recipes/:id/nutrition", routes.Recipes.FetchRecipeNutrition)
+	engine.GET("/recipes/:id/export", routes.Recipes.ExportRecipe)
+	engine.GET("/recipes/search", routes.Recipes.SearchRecipesByTag)
+	engine.GET("/recipes/:id/reviews", routes.Reviews.FetchReviews)
+	engine.GET("/images/*key", routes.Images.ServeImage)
+	engine.GET("/collections/:id", auth.Optional(routes.Auth), routes.Collections.FetchOneCollection)
+	engine.GET("/collections/:id/recipes", auth.Optional(routes.Auth), routes.Collections.FetchCollectionRecipes)
+	engine.GET("/openapi.json", openapi.Handler())
+	engine.GET("/docs/*file", openapi.UI())
+	routes.CORS.Assign(cors_middleware.Public, engine.Routes())
+
+	engine.POST("/sign-in", routes.Auth.SignIn)
+	engine.GET("/sign-out", routes.Auth.SignOut)
+
+	authorized := engine.Group("/")
+	authorized.Use(routes.Auth.Authenticate())
+	authorized.POST("/recipes", routes.Recipes.CreateNewRecipe)
+	authorized.POST("/recipes/import", routes.Recipes.ImportRecipes)
+	authorized.POST("/recipes/import/html", routes.Recipes.ImportRecipeHTML)
+	authorized.GET("/recipes/export", routes.Recipes.ExportAllRecipes)
+	authorized.PATCH("/recipes/:id", routes.Recipes.UpdateRecipe)
+	authorized.DELETE("/recipes/:id", routes.Recipes.DeleteRecipe)
+	authorized.PUT("/recipes/:id/reviews", routes.Reviews.SaveReview)
+	authorized.DELETE("/recipes/:id/reviews", routes.Reviews.DeleteReview)
+	authorized.PUT("/recipes/:id/favorite", routes.Activity.AddFavorite)
+	authorized.DELETE("/recipes/:id/favorite", routes.Activity.RemoveFavorite)
+	authorized.POST("/recipes/:id/cooked", routes.Activity.LogCooking)
+	authorized.POST("/recipes/:id/images", routes.Images.UploadRecipeImage)
+	authorized.DELETE("/recipes/:id/images/:imageId", routes.Images.DeleteRecipeImage)
+	authorized.GET("/favorites", routes.Activity.FetchFavorites)
+	authorized.GET("/cooking-history", routes.Activity.FetchCookingHistory)
+	authorized.GET("/status", RequireAdmin(routes.Admins), routes.Health.Status)
+
+	authorized.POST("/shopping-lists", routes.ShoppingLists.CreateShoppingList)
+	authorized.GET("/shopping-lists", routes.ShoppingLists.FetchShoppingLists)
+	authorized.GET("/shopping-lists/:id", routes.ShoppingLists.FetchOneShoppingList)
+	authorized.PATCH("/shopping-lists/:id/items/:index", routes.ShoppingLists.CheckShoppingItem)
+	authorized.DELETE("/shopping-lists/:id", routes.ShoppingLists.DeleteShoppingList)
+
+	authorized.POST("/meal-plans", routes.MealPlans.CreateMealPlan)
+	authorized.POST("/meal-plans/generate", routes.MealPlans.GenerateMealPlan)
+	authorized.GET("/meal-plans", routes.MealPlans.FetchMealPlans)
+	authorized.GET("/meal-plans/:id", routes.MealPlans.FetchOneMealPlan)
+	authorized.GET("/meal-plans/:id/calendar.ics", routes.MealPlans.ExportMealPlanCalendar)
+	authorized.PUT("/meal-plans/:id", routes.MealPlans.UpdateMealPlan)
+	authorized.DELETE("/meal-plans/:id", routes.MealPlans.DeleteMealPlan)
+
+	authorized.POST("/collections", routes.Collections.CreateCollection)
+	authorized.GET("/collections", routes.Collections.FetchCollections)
+	authorized.PATCH("/collections/:id", routes.Collections.UpdateCollection)
+	authorized.POST("/collections/:id/recipes", routes.Collections.AddCollectionRecipe)
+	authorized.PUT("/collections/:id/recipes", routes.Collections.ReorderCollection)
+	authorized.DELETE("/collections/:id/recipes/:recipeId", routes.Collections.RemoveCollectionRecipe)
+	authorized.DELETE("/collections/:id", routes.Collections.DeleteCollection)
+
+	// queries are public and mutations authenticate inside the handler
+	engine.GET("/graphql", routes.GraphQL.Authenticate(routes.Auth), routes.GraphQL.Serve)
+	engine.POST("/graphql", routes.GraphQL.Authenticate(routes.Auth), routes.GraphQL.Serve)
+
+	routes.CORS.Assign(cors_middleware.Authorized, engine.Routes())
+
+	return engine
+}
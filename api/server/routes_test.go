@@ -0,0 +1,92 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/config"
+	"github.com/tolopsy/foodpro/api/openapi"
+	jwt_auth "github.com/tolopsy/foodpro/api/server/middleware/authentication/jwt"
+	cors_middleware "github.com/tolopsy/foodpro/api/server/middleware/cors"
+)
+
+// testEngine registers the API's routes without connecting to any store.
+// JWT authentication is used because it adds the most routes of its own.
+func testEngine(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	corsRouter, err := cors_middleware.NewRouter(config.Default().CORS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewEngine(Routes{
+		Health: NewHealthHandler(nil),
+		Auth:   jwt_auth.NewJWTAuth("secret", nil),
+		CORS:   corsRouter,
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+}
+
+var routeParameter = regexp.MustCompile(`[:*]([^/]+)`)
+
+// specPath converts a gin route such as /recipes/:id to its OpenAPI form,
+// /recipes/{id}.
+func specPath(route string) string {
+	return routeParameter.ReplaceAllString(route, "{$1}")
+}
+
+func specOperations(t *testing.T) map[string]bool {
+	t.Helper()
+	var document struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openapi.Document(), &document); err != nil {
+		t.Fatal("OpenAPI document is not valid JSON: " + err.Error())
+	}
+	operations := make(map[string]bool)
+	for path, methods := range document.Paths {
+		for method := range methods {
+			operations[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	return operations
+}
+
+func TestEveryRouteIsInOpenAPIDocument(t *testing.T) {
+	operations := specOperations(t)
+	var missing []string
+	for _, route := range testEngine(t).Routes() {
+		operation := route.Method + " " + specPath(route.Path)
+		if !operations[operation] {
+			missing = append(missing, operation)
+		}
+	}
+	sort.Strings(missing)
+	for _, operation := range missing {
+		t.Errorf("%s is registered but missing from openapi/openapi.json", operation)
+	}
+}
+
+func TestEveryOpenAPIOperationIsRouted(t *testing.T) {
+	routed := make(map[string]bool)
+	for _, route := range testEngine(t).Routes() {
+		routed[route.Method+" "+specPath(route.Path)] = true
+	}
+	var stale []string
+	for operation := range specOperations(t) {
+		if !routed[operation] {
+			stale = append(stale, operation)
+		}
+	}
+	sort.Strings(stale)
+	for _, operation := range stale {
+		t.Errorf("%s is in openapi/openapi.json but no route serves it", operation)
+	}
+}
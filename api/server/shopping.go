@@ -0,0 +1,159 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/db"
+	"github.com/tolopsy/foodpro/api/shopping"
+)
+
+type ShoppingHandler struct {
+	db    persistence.DatabaseHandler
+	lists persistence.ShoppingListHandler
+}
+
+func NewShoppingHandler(db persistence.DatabaseHandler, lists persistence.ShoppingListHandler) *ShoppingHandler {
+	return &ShoppingHandler{
+		db:    db,
+		lists: lists,
+	}
+}
+
+type shoppingListRequest struct {
+	Name    string                           `json:"name"`
+	Recipes []persistence.ShoppingListRecipe `json:"recipes" binding:"required,min=1,dive"`
+}
+
+func (handler *ShoppingHandler) CreateShoppingList(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var request shoppingListRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+		return
+	}
+
+	var ingredients []persistence.Ingredient
+	for _, selected := range request.Recipes {
+		recipe, err := requestDB(ctx, handler.db).GetRecipe(selected.RecipeID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		scaled, err := recipe.Scale(selected.Servings, "")
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": recipe.Name + ": " + err.Error()})
+			return
+		}
+		ingredients = append(ingredients, scaled.Ingredients...)
+	}
+
+	list := persistence.ShoppingList{
+		Owner:   username,
+		Name:    request.Name,
+		Recipes: request.Recipes,
+		Items:   shopping.Merge(ingredients),
+	}
+	if err := handler.lists.AddShoppingList(&list); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, list)
+}
+
+func (handler *ShoppingHandler) FetchShoppingLists(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	lists, err := handler.lists.FetchShoppingLists(username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, lists)
+}
+
+func (handler *ShoppingHandler) FetchOneShoppingList(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	list, err := handler.lists.GetShoppingList(username, ctx.Param("id"))
+	if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+func (handler *ShoppingHandler) CheckShoppingItem(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	index, err := strconv.Atoi(ctx.Param("index"))
+	if err != nil || index < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Item index must be a non-negative whole number"})
+		return
+	}
+	var request struct {
+		Checked bool `json:"checked"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing request data -> " + err.Error()})
+		return
+	}
+
+	id := ctx.Param("id")
+	list, err := handler.lists.GetShoppingList(username, id)
+	if err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if index >= len(list.Items) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Shopping list has no item " + strconv.Itoa(index)})
+		return
+	}
+
+	if err := handler.lists.CheckShoppingItem(username, id, index, request.Checked); err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Shopping list item has been updated"})
+}
+
+func (handler *ShoppingHandler) DeleteShoppingList(ctx *gin.Context) {
+	username, ok := requireUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := handler.lists.DeleteShoppingList(username, ctx.Param("id")); err == db.ErrorNotFound {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusNoContent, gin.H{"message": "Shopping list has been deleted"})
+}
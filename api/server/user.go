@@ -0,0 +1,21 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/tolopsy/foodpro/api/server/middleware/authentication/identity"
+)
+
+// requireUser returns the signed in user of the request. It writes a 401
+// response and returns false when the request was authenticated without
+// one, since per-user data cannot be served to a shared API key.
+func requireUser(ctx *gin.Context) (string, bool) {
+	username := identity.Username(ctx)
+	if username == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "This endpoint requires a signed in user"})
+		return "", false
+	}
+	return username, true
+}
@@ -0,0 +1,81 @@
+package shopping
+
+import "strings"
+
+const (
+	Produce = "Produce"
+	Meat    = "Meat & Seafood"
+	Dairy   = "Dairy & Eggs"
+	Bakery  = "Bakery"
+	Spices  = "Spices & Seasonings"
+	Pantry  = "Pantry"
+	Frozen  = "Frozen"
+	Other   = "Other"
+)
+
+// aisleOrder is the order aisles are listed in, roughly the path through
+// a typical store.
+var aisleOrder = []string{Produce, Bakery, Meat, Dairy, Pantry, Spices, Frozen, Other}
+
+var aisleKeywords = []struct {
+	aisle    string
+	keywords []string
+}{
+	{Frozen, []string{"ice cream"}},
+	{Spices, []string{
+		"salt", "pepper", "cumin", "paprika", "cinnamon", "nutmeg", "oregano", "thyme",
+		"chili powder", "curry", "turmeric", "vanilla", "bay leaf", "bay leaves", "seasoning",
+	}},
+	{Dairy, []string{
+		"milk", "butter", "cream", "cheese", "cheddar", "parmesan", "mozzarella", "yogurt",
+		"yoghurt", "egg", "eggs", "ghee", "feta", "ricotta",
+	}},
+	{Meat, []string{
+		"beef", "pork", "bacon", "ham", "chicken", "turkey", "lamb", "sausage", "steak",
+		"fish", "salmon", "tuna", "cod", "shrimp", "prawn", "prawns", "crab", "mince",
+	}},
+	{Bakery, []string{"bread", "bun", "buns", "baguette", "tortilla", "tortillas", "pita", "rolls"}},
+	{Produce, []string{
+		"onion", "onions", "garlic", "carrot", "carrots", "potato", "potatoes", "tomato",
+		"tomatoes", "lettuce", "spinach", "broccoli", "bell pepper", "bell peppers",
+		"celery", "mushroom", "mushrooms", "lemon", "lemons", "lime", "limes", "apple",
+		"apples", "banana", "bananas", "ginger", "basil", "parsley", "cilantro", "avocado",
+		"cucumber", "zucchini", "herbs", "scallions",
+	}},
+	{Pantry, []string{
+		"flour", "sugar", "rice", "pasta", "spaghetti", "noodles", "oil", "vinegar",
+		"beans", "lentils", "chickpeas", "oats", "stock", "broth", "sauce", "honey",
+		"syrup", "baking", "cocoa", "chocolate", "nuts", "almonds", "walnuts", "canned",
+		"paste", "peanut butter", "coconut milk", "coconut cream",
+	}},
+}
+
+// aisleFor files an item under the aisle of the longest keyword in it, so
+// "peanut butter" goes to Pantry rather than to Dairy with "butter". Ties
+// go to the aisle listed first. Anything frozen is filed under Frozen,
+// whatever it is.
+func aisleFor(item string) string {
+	padded := " " + strings.ToLower(item) + " "
+	if strings.Contains(padded, " frozen ") {
+		return Frozen
+	}
+
+	best, bestLength := Other, 0
+	for _, aisle := range aisleKeywords {
+		for _, keyword := range aisle.keywords {
+			if len(keyword) > bestLength && strings.Contains(padded, " "+keyword+" ") {
+				best, bestLength = aisle.aisle, len(keyword)
+			}
+		}
+	}
+	return best
+}
+
+func aisleRank(aisle string) int {
+	for i, name := range aisleOrder {
+		if name == aisle {
+			return i
+		}
+	}
+	return len(aisleOrder)
+}
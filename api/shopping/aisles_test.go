@@ -0,0 +1,37 @@
+package shopping
+
+import "testing"
+
+func TestAisleFor(t *testing.T) {
+	tests := map[string]string{
+		"onions":              Produce,
+		"Red Bell Pepper":     Produce,
+		"black pepper":        Spices,
+		"butter":              Dairy,
+		"peanut butter":       Pantry,
+		"coconut milk":        Pantry,
+		"milk":                Dairy,
+		"chicken breast":      Meat,
+		"sourdough bread":     Bakery,
+		"frozen peas":         Frozen,
+		"frozen chicken":      Frozen,
+		"vanilla ice cream":   Frozen,
+		"sour cream":          Dairy,
+		"dragon fruit":        Other,
+		"buttermilk pancakes": Other,
+	}
+	for item, want := range tests {
+		if got := aisleFor(item); got != want {
+			t.Errorf("aisleFor(%q) = %q, want %q", item, got, want)
+		}
+	}
+}
+
+func TestAisleRank(t *testing.T) {
+	if aisleRank(Produce) >= aisleRank(Dairy) || aisleRank(Frozen) >= aisleRank(Other) {
+		t.Error("aisles are not ranked in store order")
+	}
+	if got := aisleRank("Garden Centre"); got != len(aisleOrder) {
+		t.Errorf("aisleRank of an unknown aisle = %d, want %d", got, len(aisleOrder))
+	}
+}
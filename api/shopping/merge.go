@@ -0,0 +1,91 @@
+// Package shopping turns recipe ingredients into a deduplicated shopping
+// list grouped by store aisle.
+package shopping
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/units"
+)
+
+// entry accumulates the amounts of one item. Masses and volumes are summed
+// in grams and millilitres; anything else is summed in its own unit.
+type entry struct {
+	item   string
+	unit   string
+	kind   units.Kind
+	amount float64
+	// the measurement system of the first recipe using the item, which the
+	// merged amount is reported in
+	system   units.System
+	optional bool
+}
+
+// Merge combines the ingredients of several recipes into shopping items.
+// Like items are summed when their units are compatible and kept apart when
+// they are not, e.g. "2 cloves garlic" and "1 tsp garlic".
+func Merge(ingredients []persistence.Ingredient) []persistence.ShoppingItem {
+	entries := make(map[string]*entry)
+	var order []string
+
+	for _, ingredient := range ingredients {
+		unit := ingredient.Unit
+		kind := units.KindOf(unit)
+		key := persistence.NormalizeItem(ingredient.Item) + "|" + unit
+		amount := ingredient.Quantity
+		var system units.System
+		switch kind {
+		case units.Mass:
+			key = persistence.NormalizeItem(ingredient.Item) + "|mass"
+			amount, _ = units.Convert(amount, unit, "g")
+			system = units.SystemOf(unit)
+		case units.Volume:
+			key = persistence.NormalizeItem(ingredient.Item) + "|volume"
+			amount, _ = units.Convert(amount, unit, "ml")
+			system = units.SystemOf(unit)
+		}
+
+		existing, ok := entries[key]
+		if !ok {
+			existing = &entry{item: ingredient.Item, unit: unit, kind: kind, system: system, optional: true}
+			entries[key] = existing
+			order = append(order, key)
+		}
+		existing.amount += amount
+		// an item is only optional if every recipe treats it as optional
+		existing.optional = existing.optional && ingredient.Optional
+	}
+
+	items := make([]persistence.ShoppingItem, 0, len(order))
+	for _, key := range order {
+		items = append(items, entries[key].toItem())
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if rankI, rankJ := aisleRank(items[i].Aisle), aisleRank(items[j].Aisle); rankI != rankJ {
+			return rankI < rankJ
+		}
+		return strings.ToLower(items[i].Item) < strings.ToLower(items[j].Item)
+	})
+	return items
+}
+
+func (e *entry) toItem() persistence.ShoppingItem {
+	item := persistence.ShoppingItem{
+		Item:     e.item,
+		Aisle:    aisleFor(e.item),
+		Optional: e.optional,
+	}
+	if e.amount == 0 {
+		return item
+	}
+
+	quantity, unit := e.amount, e.unit
+	if e.kind != units.Other {
+		quantity, unit = units.FromBase(e.amount, e.kind, e.system)
+	}
+	item.Quantity = units.Round(quantity, unit)
+	item.Unit = unit
+	return item
+}
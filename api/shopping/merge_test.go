@@ -0,0 +1,51 @@
+package shopping
+
+import (
+	"testing"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+func TestMerge(t *testing.T) {
+	ingredients := []persistence.Ingredient{
+		{Quantity: 2, Unit: "clove", Item: "garlic"},
+		{Quantity: 1, Unit: "cup", Item: "milk"},
+		{Quantity: 200, Unit: "g", Item: "flour"},
+		{Quantity: 2, Item: "onions"},
+		{Item: "salt", Optional: true},
+		{Item: "parsley", Optional: true},
+		{Quantity: 1, Unit: "clove", Item: "Garlic"},
+		{Quantity: 1, Unit: "tsp", Item: "garlic"},
+		{Quantity: 250, Unit: "ml", Item: "milk"},
+		{Quantity: 1, Unit: "lb", Item: "flour"},
+		{Quantity: 1, Item: "onion"},
+		{Item: "salt"},
+		{Item: "parsley", Optional: true},
+	}
+	want := []persistence.ShoppingItem{
+		{Item: "garlic", Quantity: 3, Unit: "clove", Aisle: Produce},
+		{Item: "garlic", Quantity: 1, Unit: "tsp", Aisle: Produce},
+		{Item: "onions", Quantity: 3, Aisle: Produce},
+		{Item: "parsley", Aisle: Produce, Optional: true},
+		// amounts are reported in the system of the first recipe using them
+		{Item: "milk", Quantity: 2, Unit: "cup", Aisle: Dairy},
+		{Item: "flour", Quantity: 654, Unit: "g", Aisle: Pantry},
+		{Item: "salt", Aisle: Spices},
+	}
+
+	got := Merge(ingredients)
+	if len(got) != len(want) {
+		t.Fatalf("Merge returned %d items, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("item %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMergeEmpty(t *testing.T) {
+	if got := Merge(nil); got == nil || len(got) != 0 {
+		t.Errorf("Merge(nil) = %#v, want an empty list", got)
+	}
+}
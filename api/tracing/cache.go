@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+	"github.com/tolopsy/foodpro/api/persistence/cache"
+)
+
+// CacheHandler is the CacheHandler counterpart of DatabaseHandler. A
+// lookup of a missing key is recorded as a miss rather than an error.
+type CacheHandler struct {
+	next    persistence.CacheHandler
+	system  string
+	context context.Context
+}
+
+func InstrumentCache(next persistence.CacheHandler, system string) *CacheHandler {
+	return &CacheHandler{next: next, system: system, context: context.Background()}
+}
+
+func (handler *CacheHandler) Unwrap() persistence.CacheHandler {
+	return handler.next
+}
+
+func (handler *CacheHandler) WithContext(ctx context.Context) persistence.CacheHandler {
+	return &CacheHandler{next: handler.next, system: handler.system, context: ctx}
+}
+
+func (handler *CacheHandler) start(parent context.Context, method string) (persistence.CacheHandler, trace.Span) {
+	ctx, span := tracer().Start(parent, "CacheHandler."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(handler.system),
+			semconv.DBOperation(method),
+		),
+	)
+	return handler.next.WithContext(ctx), span
+}
+
+func (handler *CacheHandler) SetRecipes(recipes []persistence.Recipe) (err error) {
+	next, span := handler.start(handler.context, "SetRecipes")
+	defer func() { end(span, err) }()
+	return next.SetRecipes(recipes)
+}
+
+func (handler *CacheHandler) GetRecipes() (recipes []persistence.Recipe, err error) {
+	next, span := handler.start(handler.context, "GetRecipes")
+	defer func() {
+		span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+		if err == cache.ErrorKeyDoesNotExist {
+			span.End()
+			return
+		}
+		end(span, err)
+	}()
+	return next.GetRecipes()
+}
+
+func (handler *CacheHandler) ClearRecipes() (err error) {
+	next, span := handler.start(handler.context, "ClearRecipes")
+	defer func() { end(span, err) }()
+	return next.ClearRecipes()
+}
+
+func (handler *CacheHandler) Ping(ctx context.Context) (err error) {
+	next, span := handler.start(ctx, "Ping")
+	defer func() { end(span, err) }()
+	return next.Ping(trace.ContextWithSpan(ctx, span))
+}
+
+func (handler *CacheHandler) ServerVersion(ctx context.Context) (version string, err error) {
+	next, span := handler.start(ctx, "ServerVersion")
+	defer func() { end(span, err) }()
+	return next.ServerVersion(trace.ContextWithSpan(ctx, span))
+}
+
+func (handler *CacheHandler) Close() error {
+	return handler.next.Close()
+}
@@ -0,0 +1,148 @@
+package tracing
+
+import (
+	"context"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tolopsy/foodpro/api/persistence"
+)
+
+// DatabaseHandler wraps a DatabaseHandler with a client span around every
+// call. Spans are children of the span in the context given to
+// WithContext, and the wrapped handler runs under the new span's context.
+type DatabaseHandler struct {
+	next    persistence.DatabaseHandler
+	system  string
+	context context.Context
+}
+
+// InstrumentDatabase wraps next, whose backend is named by system, such as
+// mongodb.
+func InstrumentDatabase(next persistence.DatabaseHandler, system string) *DatabaseHandler {
+	return &DatabaseHandler{next: next, system: system, context: context.Background()}
+}
+
+// Unwrap returns the wrapped handler, for code that needs the concrete
+// backend.
+func (db *DatabaseHandler) Unwrap() persistence.DatabaseHandler {
+	return db.next
+}
+
+func (db *DatabaseHandler) WithContext(ctx context.Context) persistence.DatabaseHandler {
+	return &DatabaseHandler{next: db.next, system: db.system, context: ctx}
+}
+
+// start opens the span for method and returns the wrapped handler bound to
+// it.
+func (db *DatabaseHandler) start(parent context.Context, method string) (persistence.DatabaseHandler, trace.Span) {
+	ctx, span := tracer().Start(parent, "DatabaseHandler."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(db.system),
+			semconv.DBOperation(method),
+		),
+	)
+	return db.next.WithContext(ctx), span
+}
+
+func (db *DatabaseHandler) FetchAllRecipes() (recipes []persistence.Recipe, err error) {
+	next, span := db.start(db.context, "FetchAllRecipes")
+	defer func() { end(span, err) }()
+	return next.FetchAllRecipes()
+}
+
+func (db *DatabaseHandler) EachRecipe(fn func(persistence.Recipe) error) (err error) {
+	next, span := db.start(db.context, "EachRecipe")
+	defer func() { end(span, err) }()
+	return next.EachRecipe(fn)
+}
+
+func (db *DatabaseHandler) EachMatchingRecipe(filter persistence.RecipeFilter, fn func(persistence.Recipe) error) (err error) {
+	next, span := db.start(db.context, "EachMatchingRecipe")
+	defer func() { end(span, err) }()
+	return next.EachMatchingRecipe(filter, fn)
+}
+
+func (db *DatabaseHandler) GetRecipe(id string) (recipe persistence.Recipe, err error) {
+	next, span := db.start(db.context, "GetRecipe")
+	defer func() { end(span, err) }()
+	return next.GetRecipe(id)
+}
+
+func (db *DatabaseHandler) FetchRecipesByIDs(ids []string) (recipes []persistence.Recipe, err error) {
+	next, span := db.start(db.context, "FetchRecipesByIDs")
+	defer func() { end(span, err) }()
+	return next.FetchRecipesByIDs(ids)
+}
+
+func (db *DatabaseHandler) FindRecipesByTag(tag string) (recipes []persistence.Recipe, err error) {
+	next, span := db.start(db.context, "FindRecipesByTag")
+	defer func() { end(span, err) }()
+	return next.FindRecipesByTag(tag)
+}
+
+func (db *DatabaseHandler) SearchRecipes(filter persistence.RecipeFilter) (recipes []persistence.Recipe, err error) {
+	next, span := db.start(db.context, "SearchRecipes")
+	defer func() { end(span, err) }()
+	return next.SearchRecipes(filter)
+}
+
+func (db *DatabaseHandler) AddRecipe(recipe *persistence.Recipe) (err error) {
+	next, span := db.start(db.context, "AddRecipe")
+	defer func() { end(span, err) }()
+	return next.AddRecipe(recipe)
+}
+
+func (db *DatabaseHandler) AddRecipes(recipes []*persistence.Recipe) (err error) {
+	next, span := db.start(db.context, "AddRecipes")
+	defer func() { end(span, err) }()
+	return next.AddRecipes(recipes)
+}
+
+func (db *DatabaseHandler) UpdateRecipe(id string, recipe persistence.Recipe, version int64) (err error) {
+	next, span := db.start(db.context, "UpdateRecipe")
+	defer func() { end(span, err) }()
+	return next.UpdateRecipe(id, recipe, version)
+}
+
+func (db *DatabaseHandler) DeleteRecipe(id string, version int64) (images []persistence.RecipeImage, err error) {
+	next, span := db.start(db.context, "DeleteRecipe")
+	defer func() { end(span, err) }()
+	return next.DeleteRecipe(id, version)
+}
+
+func (db *DatabaseHandler) AddRecipeImage(id string, image persistence.RecipeImage) (err error) {
+	next, span := db.start(db.context, "AddRecipeImage")
+	defer func() { end(span, err) }()
+	return next.AddRecipeImage(id, image)
+}
+
+func (db *DatabaseHandler) RemoveRecipeImage(id, imageID string) (image persistence.RecipeImage, err error) {
+	next, span := db.start(db.context, "RemoveRecipeImage")
+	defer func() { end(span, err) }()
+	return next.RemoveRecipeImage(id, imageID)
+}
+
+func (db *DatabaseHandler) VerifyUser(user persistence.User) bool {
+	next, span := db.start(db.context, "VerifyUser")
+	defer span.End()
+	return next.VerifyUser(user)
+}
+
+func (db *DatabaseHandler) Ping(ctx context.Context) (err error) {
+	next, span := db.start(ctx, "Ping")
+	defer func() { end(span, err) }()
+	return next.Ping(trace.ContextWithSpan(ctx, span))
+}
+
+func (db *DatabaseHandler) ServerVersion(ctx context.Context) (version string, err error) {
+	next, span := db.start(ctx, "ServerVersion")
+	defer func() { end(span, err) }()
+	return next.ServerVersion(trace.ContextWithSpan(ctx, span))
+}
+
+func (db *DatabaseHandler) Close() error {
+	return db.next.Close()
+}
@@ -0,0 +1,171 @@
+// Package units knows how kitchen units relate to each other so recipe
+// quantities can be scaled, converted between measurement systems and
+// rounded the way a cook would write them.
+package units
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+type Kind int
+
+const (
+	Other Kind = iota
+	Volume
+	Mass
+)
+
+type System string
+
+const (
+	Metric   System = "metric"
+	Imperial System = "imperial"
+)
+
+var ErrorIncompatibleUnits = errors.New("units measure different things and cannot be converted")
+var ErrorUnknownSystem = errors.New("unknown measurement system")
+
+type unit struct {
+	kind Kind
+	// size of the unit in millilitres for volumes and grams for masses
+	base   float64
+	system System
+	// step quantities are rounded to; fractional steps are printed as fractions
+	step       float64
+	fractional bool
+}
+
+var table = map[string]unit{
+	"tsp":    {Volume, 4.92892, Imperial, 0.125, true},
+	"tbsp":   {Volume, 14.7868, Imperial, 0.5, true},
+	"fl oz":  {Volume, 29.5735, Imperial, 0.5, true},
+	"cup":    {Volume, 236.588, Imperial, 0.125, true},
+	"pint":   {Volume, 473.176, Imperial, 0.25, true},
+	"quart":  {Volume, 946.353, Imperial, 0.25, true},
+	"gallon": {Volume, 3785.41, Imperial, 0.25, true},
+	"ml":     {Volume, 1, Metric, 1, false},
+	"l":      {Volume, 1000, Metric, 0.01, false},
+	"g":      {Mass, 1, Metric, 1, false},
+	"kg":     {Mass, 1000, Metric, 0.01, false},
+	"oz":     {Mass, 28.3495, Imperial, 0.25, true},
+	"lb":     {Mass, 453.592, Imperial, 0.25, true},
+}
+
+// KindOf reports what a canonical unit measures. Unknown units, and counts
+// such as "clove" or "can", are Other.
+func KindOf(name string) Kind {
+	return table[name].kind
+}
+
+// Convert expresses quantity in another unit of the same kind.
+func Convert(quantity float64, from, to string) (float64, error) {
+	if from == to {
+		return quantity, nil
+	}
+	source, ok := table[from]
+	target, found := table[to]
+	if !ok || !found || source.kind != target.kind {
+		return 0, ErrorIncompatibleUnits
+	}
+	return quantity * source.base / target.base, nil
+}
+
+// ToSystem converts quantity into the most readable unit of the target
+// system. Units that cannot be converted are returned unchanged.
+func ToSystem(quantity float64, name string, system System) (float64, string, error) {
+	if system != Metric && system != Imperial {
+		return 0, "", ErrorUnknownSystem
+	}
+	source, ok := table[name]
+	if !ok || source.kind == Other || source.system == system {
+		return quantity, name, nil
+	}
+
+	amount := quantity * source.base
+	target := pick(amount, source.kind, system)
+	return amount / table[target].base, target, nil
+}
+
+// SystemOf reports which measurement system a unit belongs to. Counts and
+// unknown units belong to neither and return an empty System.
+func SystemOf(name string) System {
+	return table[name].system
+}
+
+// FromBase expresses an amount in millilitres or grams in the most readable
+// unit of system.
+func FromBase(amount float64, kind Kind, system System) (float64, string) {
+	target := pick(amount, kind, system)
+	return amount / table[target].base, target
+}
+
+// pick chooses the unit of a system a recipe would use for an amount given
+// in millilitres or grams.
+func pick(amount float64, kind Kind, system System) string {
+	switch {
+	case kind == Volume && system == Metric:
+		if amount >= 1000 {
+			return "l"
+		}
+		return "ml"
+	case kind == Volume:
+		if amount >= table["cup"].base/4 {
+			return "cup"
+		}
+		if amount >= table["tbsp"].base {
+			return "tbsp"
+		}
+		return "tsp"
+	case system == Metric:
+		if amount >= 1000 {
+			return "kg"
+		}
+		return "g"
+	default:
+		if amount >= table["lb"].base {
+			return "lb"
+		}
+		return "oz"
+	}
+}
+
+// Round snaps quantity to the precision usually written for the unit:
+// eighths of a cup, whole grams and so on. Counts are rounded to quarters.
+func Round(quantity float64, name string) float64 {
+	step := 0.25
+	if u, ok := table[name]; ok {
+		step = u.step
+	}
+	rounded := math.Round(quantity/step) * step
+	if rounded == 0 && quantity > 0 {
+		return step
+	}
+	return rounded
+}
+
+var fractionNames = map[int]string{1: "1/8", 2: "1/4", 3: "3/8", 4: "1/2", 5: "5/8", 6: "3/4", 7: "7/8"}
+
+// Format prints quantity for the given unit, using mixed fractions such as
+// "1 1/2" for imperial measures and plain decimals otherwise.
+func Format(quantity float64, name string) string {
+	u, known := table[name]
+	if known && !u.fractional {
+		return strconv.FormatFloat(math.Round(quantity*100)/100, 'f', -1, 64)
+	}
+
+	eighths := int(math.Round(quantity * 8))
+	if math.Abs(float64(eighths)-quantity*8) > 0.01 {
+		return strconv.FormatFloat(math.Round(quantity*100)/100, 'f', -1, 64)
+	}
+	whole, rest := eighths/8, eighths%8
+	switch {
+	case rest == 0:
+		return strconv.Itoa(whole)
+	case whole == 0:
+		return fractionNames[rest]
+	default:
+		return strconv.Itoa(whole) + " " + fractionNames[rest]
+	}
+}
@@ -0,0 +1,91 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-3
+}
+
+func TestConvert(t *testing.T) {
+	if got, err := Convert(1, "cup", "ml"); err != nil || !near(got, 236.588) {
+		t.Errorf("Convert(1, cup, ml) = %v, %v, want 236.588", got, err)
+	}
+	if got, err := Convert(2, "kg", "lb"); err != nil || !near(got, 4.409) {
+		t.Errorf("Convert(2, kg, lb) = %v, %v, want 4.409", got, err)
+	}
+	if got, err := Convert(3, "clove", "clove"); err != nil || got != 3 {
+		t.Errorf("Convert(3, clove, clove) = %v, %v, want 3", got, err)
+	}
+	if _, err := Convert(1, "cup", "g"); err != ErrorIncompatibleUnits {
+		t.Errorf("Convert(1, cup, g) error = %v, want %v", err, ErrorIncompatibleUnits)
+	}
+}
+
+func TestToSystem(t *testing.T) {
+	tests := []struct {
+		quantity float64
+		unit     string
+		system   System
+		want     float64
+		wantUnit string
+	}{
+		{1, "cup", Metric, 236.588, "ml"},
+		{5, "cup", Metric, 1.18294, "l"},
+		{500, "g", Imperial, 1.10231, "lb"},
+		{100, "g", Imperial, 3.52740, "oz"},
+		{30, "ml", Imperial, 2.02884, "tbsp"},
+		{2, "tbsp", Imperial, 2, "tbsp"},
+		{2, "clove", Metric, 2, "clove"},
+	}
+	for _, test := range tests {
+		got, unit, err := ToSystem(test.quantity, test.unit, test.system)
+		if err != nil || unit != test.wantUnit || !near(got, test.want) {
+			t.Errorf("ToSystem(%v, %s, %s) = %v %s, %v, want %v %s", test.quantity, test.unit, test.system, got, unit, err, test.want, test.wantUnit)
+		}
+	}
+	if _, _, err := ToSystem(1, "cup", "si"); err != ErrorUnknownSystem {
+		t.Errorf("ToSystem to an unknown system error = %v, want %v", err, ErrorUnknownSystem)
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		quantity float64
+		unit     string
+		want     float64
+	}{
+		{0.3, "cup", 0.25},
+		{236.588, "ml", 237},
+		{0.01, "cup", 0.125},
+		{1.1, "clove", 1},
+		{1.234, "kg", 1.23},
+	}
+	for _, test := range tests {
+		if got := Round(test.quantity, test.unit); !near(got, test.want) {
+			t.Errorf("Round(%v, %s) = %v, want %v", test.quantity, test.unit, got, test.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		quantity float64
+		unit     string
+		want     string
+	}{
+		{1.5, "cup", "1 1/2"},
+		{0.125, "tsp", "1/8"},
+		{2, "clove", "2"},
+		{237, "ml", "237"},
+		{1.25, "l", "1.25"},
+		{1.1, "lb", "1.1"},
+	}
+	for _, test := range tests {
+		if got := Format(test.quantity, test.unit); got != test.want {
+			t.Errorf("Format(%v, %s) = %q, want %q", test.quantity, test.unit, got, test.want)
+		}
+	}
+}
@@ -1,12 +1,34 @@
 import React from "react";
 
+const formatIngredient = (ingredient) => {
+  // older API responses still send plain strings
+  if (typeof ingredient === "string") {
+    return ingredient
+  }
+  let line = [ingredient.quantity, ingredient.unit, ingredient.item].filter(Boolean).join(" ")
+  if (ingredient.note) {
+    line += ", " + ingredient.note
+  }
+  if (ingredient.optional) {
+    line += " (optional)"
+  }
+  return line
+}
+
+// the API returns paths for images it serves itself and full URLs otherwise
+const imageSource = (url) => url.startsWith("http") ? url : "http://localhost:8080" + url
+
 const Recipe = (props) => {
   return (
     <div class="recipe">
       <h4>{props.recipe.name}</h4>
+      {
+        props.recipe.images && props.recipe.images.length > 0 &&
+        <img src={imageSource(props.recipe.images[0].thumbnailUrl)} alt={props.recipe.name} />
+      }
       <ul>
         {
-          props.recipe.ingredients && props.recipe.ingredients.map((ingredient, index) => <li>{ingredient}</li>)
+          props.recipe.ingredients && props.recipe.ingredients.map((ingredient, index) => <li>{formatIngredient(ingredient)}</li>)
         }
       </ul>
     </div>